@@ -1,7 +1,6 @@
 package giu
 
 import (
-	"fmt"
 	"image"
 
 	"github.com/AllenDang/cimgui-go/imgui"
@@ -81,6 +80,7 @@ var _ Widget = &AlignmentSetter{}
 // NOTE: Because of AlignSetter uses experimental GetWidgetWidth,
 // it is experimental too.
 // usage: see examples/align
+// See also Flex, which measures widgets while building them.
 //
 // list of known bugs:
 // - BUG: there is some bug with SelectableWidget.
@@ -149,7 +149,8 @@ func (a *AlignmentSetter) Build() {
 		case AlignRight:
 			SetCursorPos(image.Pt(int(availableW-w)+currentPos.X, currentPos.Y))
 		default:
-			panic(fmt.Sprintf("giu: (*AlignSetter).Build: unknown align type %d", a.alignType))
+			AssertID(false, "AlignmentSetter", "Build", a.id, "unknown align type %d", a.alignType)
+			SetCursorPos(currentPos)
 		}
 
 		// build aligned widget
@@ -174,6 +175,42 @@ func (a *AlignmentSetter) Build() {
 // if you find anything else, please report it on
 // https://github.com/AllenDang/giu Any contribution is appreciated!
 func GetWidgetWidth(w Widget) (result float32) {
+	measureWidget(w, func(startPos image.Point) {
+		// save widget's width
+		// check cursor position
+		imgui.SameLine()
+
+		spacingW, _ := GetItemSpacing()
+		result = float32(GetCursorPos().X-startPos.X) - spacingW
+
+		// Undo SameLine (see https://github.com/AllenDang/giu/issues/807)
+		imgui.NewLine()
+	})
+
+	return result
+}
+
+// GetWidgetSize returns a size of widget. It is measured the same way as in GetWidgetWidth,
+// but widget is built in a group, so its height is known too (and all its widgets are measured).
+// NOTE: w is built (invisibly), so a widget measured and then built is built twice per frame.
+func GetWidgetSize(w Widget) (width, height float32) {
+	group := Custom(func() {
+		imgui.BeginGroup()
+		w.Build()
+		imgui.EndGroup()
+	})
+
+	measureWidget(group, func(image.Point) {
+		size := imgui.ItemRectSize()
+		width, height = size.X, size.Y
+	})
+
+	return width, height
+}
+
+// measureWidget builds w invisibly out of the working space and calls measure (with position the widget was built at).
+// Cursor position is restored afterwards.
+func measureWidget(w Widget, measure func(startPos image.Point)) {
 	imgui.PushIDStr(string(GenAutoID("GetWidgetWidthMeasurement")))
 
 	defer imgui.PopID()
@@ -190,18 +227,8 @@ func GetWidgetWidth(w Widget) (result float32) {
 	w.Build()
 	imgui.PopStyleVar()
 
-	// save widget's width
-	// check cursor position
-	imgui.SameLine()
-
-	spacingW, _ := GetItemSpacing()
-	result = float32(GetCursorPos().X-startPos.X) - spacingW
-
-	// Undo SameLine (see https://github.com/AllenDang/giu/issues/807)
-	imgui.NewLine()
+	measure(startPos)
 
 	// reset drawing cursor position
 	SetCursorPos(currentPos)
-
-	return result
 }
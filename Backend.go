@@ -38,14 +38,22 @@ func NewGLFWBackend() *GLFWBackend {
 
 // SetInputMode implements backend.Backend interface.
 func (b *GLFWBackend) SetInputMode(mode, _ MasterWindowFlags) {
-	flag := b.parseFlag(mode)
+	flag, ok := b.parseFlag(mode)
+	if !ok {
+		return
+	}
+
 	b.GLFWBackend.SetInputMode(flag.flag, glfwbackend.GLFWWindowFlags(flag.value))
 }
 
 // SetSwapInterval implements backend.Backend interface.
 func (b *GLFWBackend) SetSwapInterval(interval MasterWindowFlags) error {
-	intervalV := b.parseFlag(interval).flag
-	if err := b.GLFWBackend.SetSwapInterval(intervalV); err != nil {
+	intervalV, ok := b.parseFlag(interval)
+	if !ok {
+		return fmt.Errorf("giu.GLFWBackend got unknown swap interval %d", interval)
+	}
+
+	if err := b.GLFWBackend.SetSwapInterval(intervalV.flag); err != nil {
 		return fmt.Errorf("giu.GLFWBackend got error while SwapInterval: %w", err)
 	}
 
@@ -54,11 +62,15 @@ func (b *GLFWBackend) SetSwapInterval(interval MasterWindowFlags) error {
 
 // SetWindowFlags implements backend.Backend interface.
 func (b *GLFWBackend) SetWindowFlags(flags MasterWindowFlags, _ int) {
-	flag := b.parseFlag(flags)
+	flag, ok := b.parseFlag(flags)
+	if !ok {
+		return
+	}
+
 	b.GLFWBackend.SetWindowFlags(flag.flag, flag.value)
 }
 
-func (b *GLFWBackend) parseFlag(m MasterWindowFlags) flagValue[glfwbackend.GLFWWindowFlags] {
+func (b *GLFWBackend) parseFlag(m MasterWindowFlags) (flagValue[glfwbackend.GLFWWindowFlags], bool) {
 	data := map[MasterWindowFlags]flagValue[glfwbackend.GLFWWindowFlags]{
 		MasterWindowFlagsNotResizable: {glfwbackend.GLFWWindowFlagsResizable, 0},
 		MasterWindowFlagsMaximized:    {glfwbackend.GLFWWindowFlagsMaximized, 1},
@@ -71,5 +83,5 @@ func (b *GLFWBackend) parseFlag(m MasterWindowFlags) flagValue[glfwbackend.GLFWW
 	d, ok := data[m]
 	Assert(ok, "GLFWBackend", "parseFlag", "Unknown MasterWindowFlags")
 
-	return d
+	return d, ok
 }
@@ -2,11 +2,12 @@ package giu
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/mazznoer/csscolorparser"
-	"github.com/napsy/go-css"
 )
 
 // MainTag is a special tag that allows to apply style to the whole application (if set to Context.SetCSSStylesheet).
@@ -32,7 +33,12 @@ func (e ErrCSSParse) Error() string {
 // CSSStylesheet represents a parsed CSS stylesheet.
 // Use CSS().Parse(data) to load data from memory.
 type CSSStylesheet struct {
+	// stylesheet maps normalized selectors to their styles.
 	stylesheet map[string]*StyleSetter
+	// rules are in order of appearance (later rule wins if specificity is equal).
+	rules []*cssRule
+	// variables are custom properties (--name: value) declared in any rule.
+	variables map[string]string
 }
 
 // CSS prepares new CSSStylesheet.
@@ -40,16 +46,20 @@ type CSSStylesheet struct {
 // The intention of the code looks as follows:
 //   - The CSS stylesheet is parsed and the resulting rules are stored in giu context
 //     NOTE: You can use ParseCSSStyleSheet to parse exactly one stylesheet or create one stylesheet with CSS() and parse multiple files by Parse() (optionally use Add to merge several CSSStylesheet)
+//   - rules are matched against widgets built by Layout (by widget kind, .Class(), .CSSID() and ancestors)
 //   - CSSTagWidget allows to apply style to a specified layout
 //   - main tag allows to apply style to the whole application
 //
 // tools:
-// css parser - for now github.com/napsy/go-css. it is a bit poor, but I don't think we need anything more
+// css parser - see parseCSSBlocks and parseCSSSelector
 // css colors - github.com/mazznoer/csscolorparser
 //
 // docs: docs/css.md
 func CSS() *CSSStylesheet {
-	return &CSSStylesheet{stylesheet: make(map[string]*StyleSetter)}
+	return &CSSStylesheet{
+		stylesheet: make(map[string]*StyleSetter),
+		variables:  make(map[string]string),
+	}
 }
 
 // ParseCSSStyleSheet parses data and stores the rules in the current Context (overwrites the previous one).
@@ -67,27 +77,39 @@ func ParseCSSStyleSheet(data []byte) error {
 // Add allows to add another CSS stylesheet to the current one.
 // NOTE: modifies receiver and returns it as well.
 func (c *CSSStylesheet) Add(other *CSSStylesheet) *CSSStylesheet {
-	for k, v := range other.stylesheet {
-		if _, exists := c.stylesheet[k]; exists {
-			c.stylesheet[k].Add(v)
-			continue
-		}
+	for _, rule := range other.rules {
+		c.addRule(rule.selector, rule.style)
+	}
 
-		c.stylesheet[k] = v
+	for name, value := range other.variables {
+		c.variables[name] = value
 	}
 
 	return c
 }
 
+func (c *CSSStylesheet) addRule(selector cssSelector, style *StyleSetter) {
+	key := selector.String()
+	if existing, exists := c.stylesheet[key]; exists {
+		existing.Add(style)
+		return
+	}
+
+	style = Style().Add(style)
+	c.stylesheet[key] = style
+	c.rules = append(c.rules, &cssRule{selector: selector, style: style})
+}
+
 // HasTag returns true if the CSS stylesheet contains the specified tag.
 func (c *CSSStylesheet) HasTag(t string) bool {
-	_, exists := c.stylesheet[t]
+	_, exists := c.stylesheet[normalizeCSSSelector(t)]
 	return exists
 }
 
 // GetTag returns a style setter for the specified tag or empty Style() if no tag.
+// tag may be any selector (e.g. "button.danger:hover").
 func (c *CSSStylesheet) GetTag(tag string) (result *StyleSetter) {
-	result, exists := c.stylesheet[tag]
+	result, exists := c.stylesheet[normalizeCSSSelector(tag)]
 	if !exists {
 		return Style()
 	}
@@ -95,82 +117,329 @@ func (c *CSSStylesheet) GetTag(tag string) (result *StyleSetter) {
 	return result
 }
 
+// normalizeCSSSelector returns selector in the form used as a key of CSSStylesheet.stylesheet.
+func normalizeCSSSelector(selector string) string {
+	if parsed, err := parseCSSSelector(selector); err == nil {
+		return parsed.String()
+	}
+
+	return selector
+}
+
+// references reports whether any rule refers to the tag.
+func (c *CSSStylesheet) references(tag string) bool {
+	return slices.ContainsFunc(c.rules, func(r *cssRule) bool {
+		return r.selector.references(tag)
+	})
+}
+
 // Parse parses CSS stylesheet and stores the rules in the receiver.
-// NOTE: more than one CSS stylesheets can be parsed, however the app could panic if the same tag is present twice
+// Supported selectors are:
+//   - tags (widget kinds like button or input-text and CSSTag names; :root is an alias for MainTag)
+//   - classes (.danger) and IDs (#save) - see .Class() and .CSSID() methods of widgets
+//   - descendant selectors (toolbar button)
+//   - :hover, :active and :disabled pseudo-classes
+//   - comma-separated lists of selectors
 //
-//nolint:gocognit // no
+// Supported properties are StyleVarID, StyleColorID, StylePlotVarID and StylePlotColorID names,
+// font-family, font-size, disabled and custom properties (--name: value; used as var(--name) or var(--name, fallback)).
+// Custom properties are global - they may be used in any rule (and in stylesheets parsed later).
+//
+// NOTE: more than one CSS stylesheets can be parsed; rules with the same selector are merged.
 func (c *CSSStylesheet) Parse(data []byte) error {
-	// css does not support windows formatting
-	// https://github.com/AllenDang/giu/issues/842
-	data = []byte(strings.ReplaceAll(string(data), "\r\n", "\n"))
-
-	stylesheet, err := css.Unmarshal(data)
+	blocks, err := parseCSSBlocks(string(data))
 	if err != nil {
-		return fmt.Errorf("error marshaling CSS file: %w", err)
-	}
-
-	for rule, style := range stylesheet {
-		setter := Style()
-
-		for styleVarName, styleVarValue := range style {
-			// convert style variable name to giu style variable name
-			styleVarID, err := StyleVarIDString(styleVarName)
-			if err == nil {
-				if err := parseStyleVar(styleVarValue, func(v float32) {
-					setter.SetStyleFloat(styleVarID, v)
-				}, func(x, y float32) {
-					setter.SetStyle(styleVarID, x, y)
-				}); err != nil {
-					return err
-				}
+		return err
+	}
 
-				continue
+	// custom properties are collected first so that they can be used before they are declared
+	for _, block := range blocks {
+		for _, declaration := range block.declarations {
+			if strings.HasPrefix(declaration[0], "--") {
+				c.variables[declaration[0]] = declaration[1]
 			}
+		}
+	}
 
-			styleColorID, err := StyleColorIDString(styleVarName)
-			if err == nil {
-				col, err := csscolorparser.Parse(styleVarValue)
-				if err != nil {
-					return ErrCSSParse{What: "color", Value: styleVarValue, Detail: err}
-				}
+	for _, block := range blocks {
+		// blocks declaring custom properties only (e.g. :root written by Marshal) are not rules
+		if len(block.declarations) > 0 && !slices.ContainsFunc(block.declarations, func(d [2]string) bool {
+			return !strings.HasPrefix(d[0], "--")
+		}) {
+			continue
+		}
 
-				setter.SetColor(styleColorID, col)
+		for selectorStr := range strings.SplitSeq(block.selectors, ",") {
+			selector, err := parseCSSSelector(selectorStr)
+			if err != nil {
+				return err
+			}
 
-				continue
+			if err := selector.checkStateDeclarations(block.declarations); err != nil {
+				return err
 			}
 
-			stylePlotVarID, err := StylePlotVarIDString(styleVarName)
-			if err == nil {
-				if err := parseStyleVar(styleVarValue, func(v float32) {
-					setter.SetPlotStyleFloat(stylePlotVarID, v)
-				}, func(x, y float32) {
-					setter.SetPlotStyle(stylePlotVarID, x, y)
-				}); err != nil {
-					return err
+			setter, err := c.parseDeclarations(block.declarations)
+			if err != nil {
+				return err
+			}
+
+			c.addRule(selector, setter)
+		}
+	}
+
+	return nil
+}
+
+// maxCSSVariableDepth limits nesting of var() to detect cyclic references.
+const maxCSSVariableDepth = 32
+
+// resolveVariables replaces all var(--name) and var(--name, fallback) in value.
+func (c *CSSStylesheet) resolveVariables(value string, depth int) (string, error) {
+	if depth > maxCSSVariableDepth {
+		return "", ErrCSSParse{What: "variable (cyclic reference)", Value: value}
+	}
+
+	for {
+		start := strings.Index(value, "var(")
+		if start < 0 {
+			return value, nil
+		}
+
+		// find matching parenthesis
+		end, level := -1, 0
+
+		for i := start + len("var("); i < len(value); i++ {
+			if value[i] == '(' {
+				level++
+			} else if value[i] == ')' {
+				if level == 0 {
+					end = i
+					break
 				}
 
-				continue
+				level--
 			}
+		}
 
-			stylePlotColorID, err := StylePlotColorIDString(styleVarName)
-			if err == nil {
-				col, err := csscolorparser.Parse(styleVarValue)
-				if err != nil {
-					return ErrCSSParse{What: "color", Value: styleVarValue, Detail: err}
-				}
+		if end < 0 {
+			return "", ErrCSSParse{What: "variable (missing ))", Value: value[start:]}
+		}
+
+		name, fallback, hasFallback := strings.Cut(value[start+len("var("):end], ",")
+		name = strings.TrimSpace(name)
+
+		replacement, ok := c.variables[name]
+
+		switch {
+		case ok:
+		case hasFallback:
+			replacement = strings.TrimSpace(fallback)
+		default:
+			return "", ErrCSSParse{What: "variable (undefined)", Value: name}
+		}
+
+		replacement, err := c.resolveVariables(replacement, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		value = value[:start] + replacement + value[end+1:]
+	}
+}
+
+// cssBlock is a raw rule block: selectors { name: value; ... }.
+type cssBlock struct {
+	selectors    string
+	declarations [][2]string
+}
+
+// parseCSSBlocks splits stylesheet into rule blocks. Comments are skipped.
+func parseCSSBlocks(data string) ([]cssBlock, error) {
+	// strip comments
+	for {
+		start := strings.Index(data, "/*")
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(data[start+2:], "*/")
+		if end < 0 {
+			return nil, ErrCSSParse{What: "comment (missing */)", Value: data[start:]}
+		}
+
+		data = data[:start] + " " + data[start+2+end+2:]
+	}
+
+	var result []cssBlock
+
+	for {
+		data = strings.TrimSpace(data)
+		if data == "" {
+			return result, nil
+		}
+
+		open := strings.Index(data, "{")
+		if open < 0 {
+			return nil, ErrCSSParse{What: "rule (missing {)", Value: data}
+		}
+
+		closing := strings.Index(data, "}")
+
+		switch {
+		case closing < 0:
+			return nil, ErrCSSParse{What: "rule (missing })", Value: data}
+		case closing < open:
+			return nil, ErrCSSParse{What: "rule (unexpected })", Value: data[:open]}
+		}
+
+		block := cssBlock{selectors: strings.TrimSpace(data[:open])}
+		body := data[open+1 : closing]
 
-				setter.SetPlotColor(stylePlotColorID, col)
+		if strings.Contains(body, "{") {
+			return nil, ErrCSSParse{What: "rule (missing }; nested blocks are not supported)", Value: block.selectors}
+		}
 
+		for declaration := range strings.SplitSeq(body, ";") {
+			declaration = strings.TrimSpace(declaration)
+			if declaration == "" {
 				continue
 			}
 
-			return ErrCSSParse{What: "style variable name", Value: styleVarName}
+			name, value, found := strings.Cut(declaration, ":")
+			if !found {
+				return nil, ErrCSSParse{What: "declaration (missing :)", Value: declaration}
+			}
+
+			block.declarations = append(block.declarations, [2]string{strings.TrimSpace(name), strings.TrimSpace(value)})
 		}
 
-		c.stylesheet[string(rule)] = setter
+		result = append(result, block)
+		data = data[closing+1:]
 	}
+}
 
-	return nil
+// parseDeclarations converts declarations of a rule into StyleSetter.
+//
+//nolint:gocognit,gocyclo,cyclop // no
+func (c *CSSStylesheet) parseDeclarations(declarations [][2]string) (*StyleSetter, error) {
+	setter := Style()
+
+	for _, declaration := range declarations {
+		styleVarName := declaration[0]
+
+		// custom properties are collected by Parse
+		if strings.HasPrefix(styleVarName, "--") {
+			continue
+		}
+
+		styleVarValue, err := c.resolveVariables(declaration[1], 0)
+		if err != nil {
+			return nil, err
+		}
+
+		switch styleVarName {
+		case "font-family":
+			font, err := parseFontFamily(styleVarValue)
+			if err != nil {
+				return nil, err
+			}
+
+			setter.SetFont(font)
+
+			continue
+		case "font-size":
+			size, err := strconv.ParseFloat(strings.TrimSuffix(styleVarValue, "px"), 32)
+			if err != nil || size <= 0 {
+				return nil, ErrCSSParse{What: "font size", Value: styleVarValue, Detail: err}
+			}
+
+			setter.SetFontSize(float32(size))
+
+			continue
+		case "disabled":
+			disabled, err := strconv.ParseBool(styleVarValue)
+			if err != nil {
+				return nil, ErrCSSParse{What: "disabled (not bool)", Value: styleVarValue, Detail: err}
+			}
+
+			setter.SetDisabled(disabled)
+
+			continue
+		}
+
+		// convert style variable name to giu style variable name
+		styleVarID, err := StyleVarIDString(styleVarName)
+		if err == nil {
+			if err := parseStyleVar(styleVarValue, func(v float32) {
+				setter.SetStyleFloat(styleVarID, v)
+			}, func(x, y float32) {
+				setter.SetStyle(styleVarID, x, y)
+			}); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		styleColorID, err := StyleColorIDString(styleVarName)
+		if err == nil {
+			col, err := csscolorparser.Parse(styleVarValue)
+			if err != nil {
+				return nil, ErrCSSParse{What: "color", Value: styleVarValue, Detail: err}
+			}
+
+			// NOTE: color components are used directly (not via color.Color.RGBA) to keep them exact and not premultiplied.
+			setter.SetColorVec4(styleColorID, imgui.Vec4{X: float32(col.R), Y: float32(col.G), Z: float32(col.B), W: float32(col.A)})
+
+			continue
+		}
+
+		stylePlotVarID, err := StylePlotVarIDString(styleVarName)
+		if err == nil {
+			if err := parseStyleVar(styleVarValue, func(v float32) {
+				setter.SetPlotStyleFloat(stylePlotVarID, v)
+			}, func(x, y float32) {
+				setter.SetPlotStyle(stylePlotVarID, x, y)
+			}); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		stylePlotColorID, err := StylePlotColorIDString(styleVarName)
+		if err == nil {
+			col, err := csscolorparser.Parse(styleVarValue)
+			if err != nil {
+				return nil, ErrCSSParse{What: "color", Value: styleVarValue, Detail: err}
+			}
+
+			setter.SetPlotColor(stylePlotColorID, col)
+
+			continue
+		}
+
+		return nil, ErrCSSParse{What: "style variable name", Value: styleVarName}
+	}
+
+	return setter, nil
+}
+
+// parseFontFamily finds the first font of comma-separated list that was added to Context.FontAtlas.
+func parseFontFamily(value string) (*FontInfo, error) {
+	if Context == nil || Context.FontAtlas == nil {
+		return nil, ErrCSSParse{What: "font-family (no giu Context)", Value: value}
+	}
+
+	for name := range strings.SplitSeq(value, ",") {
+		name = strings.Trim(strings.TrimSpace(name), `"'`)
+		if font := Context.FontAtlas.FindFont(name); font != nil {
+			return font, nil
+		}
+	}
+
+	return nil, ErrCSSParse{What: "font-family (font not added to FontAtlas)", Value: value}
 }
 
 func parseStyleVar(styleVarValue string, setFloat func(v float32), setVec2 func(x, y float32)) error {
@@ -213,6 +482,8 @@ type CSSTagWidget struct {
 	tag        string
 	stylesheet *CSSStylesheet
 	layout     Layout
+
+	cssAttributes
 }
 
 // CSSTag creates CSSTagWidget.
@@ -240,10 +511,39 @@ func (c *CSSTagWidget) To(layout ...Widget) *CSSTagWidget {
 	return c
 }
 
+// Class adds CSS classes to the tag (matched by .class selectors).
+func (c *CSSTagWidget) Class(classes ...string) *CSSTagWidget {
+	c.addClasses(classes)
+	return c
+}
+
+// CSSID sets CSS ID of the tag (matched by #id selectors).
+func (c *CSSTagWidget) CSSID(id string) *CSSTagWidget {
+	c.cssID = id
+	return c
+}
+
 // Build implements Widget interface.
 func (c *CSSTagWidget) Build() {
 	// get style from context.
 	// if it doesn't exist Assert.
-	Assert(c.stylesheet.HasTag(c.tag), "CSSTagWidget", "Build", "CSS stylesheet doesn't contain tag: %s", c.tag)
-	c.GetStyle().To(c.layout).Build()
+	Assert(c.stylesheet.references(c.tag), "CSSTagWidget", "Build", "CSS stylesheet doesn't contain tag: %s", c.tag)
+
+	// the tag is usually already on the path (see applyCSS), unless Build is called directly.
+	path := Context.cssPath
+	if l := len(path); l == 0 || path[l-1].widget != c {
+		var parent *cssElement
+		if l > 0 {
+			parent = path[l-1]
+		}
+
+		path = append(path[:l:l], newCSSElement(c, parent))
+	}
+
+	style := c.stylesheet.resolve(path)
+	if style == nil {
+		style = Style()
+	}
+
+	style.To(c.layout...).Build()
 }
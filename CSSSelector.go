@@ -0,0 +1,520 @@
+package giu
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// cssPseudoState is a pseudo-class (e.g. :hover) of a CSS selector.
+type cssPseudoState byte
+
+const (
+	cssStateNone cssPseudoState = iota
+	cssStateHover
+	cssStateActive
+	cssStateDisabled
+)
+
+var cssPseudoStates = map[string]cssPseudoState{
+	"hover":    cssStateHover,
+	"active":   cssStateActive,
+	"disabled": cssStateDisabled,
+}
+
+// cssCompound is a part of selector without combinators (e.g. button.danger:hover).
+type cssCompound struct {
+	tag     string // empty or "*" matches any tag
+	id      string
+	classes []string
+	state   cssPseudoState
+}
+
+func (c cssCompound) matches(e *cssElement) bool {
+	if c.tag != "" && c.tag != "*" && c.tag != e.tag {
+		return false
+	}
+
+	if c.id != "" && c.id != e.id {
+		return false
+	}
+
+	for _, class := range c.classes {
+		if !slices.Contains(e.classes, class) {
+			return false
+		}
+	}
+
+	return c.state != cssStateDisabled || e.disabled
+}
+
+func (c cssCompound) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(c.tag)
+
+	if c.id != "" {
+		sb.WriteString("#" + c.id)
+	}
+
+	for _, class := range c.classes {
+		sb.WriteString("." + class)
+	}
+
+	for name, state := range cssPseudoStates {
+		if state == c.state {
+			sb.WriteString(":" + name)
+		}
+	}
+
+	return sb.String()
+}
+
+// cssSelector is a chain of compounds combined with descendant combinator.
+// The last compound is the selector's subject.
+type cssSelector []cssCompound
+
+// parseCSSSelector parses a single (not comma-separated) selector
+// like "toolbar button.danger:hover" or "#save".
+func parseCSSSelector(s string) (cssSelector, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, ErrCSSParse{What: "selector (empty)", Value: s}
+	}
+
+	result := make(cssSelector, 0, len(fields))
+
+	for _, field := range fields {
+		compound, err := parseCSSCompound(field)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, compound)
+	}
+
+	// pseudo states cannot be checked for ancestors (except of :disabled)
+	for _, c := range result[:len(result)-1] {
+		if c.state == cssStateHover || c.state == cssStateActive {
+			return nil, ErrCSSParse{What: "selector (:hover and :active are allowed for the last element only)", Value: s}
+		}
+	}
+
+	return result, nil
+}
+
+func parseCSSCompound(s string) (result cssCompound, err error) {
+	// read identifier starting at i
+	ident := func(i int) string {
+		end := i
+		for end < len(s) {
+			r := rune(s[end])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+				break
+			}
+
+			end++
+		}
+
+		return s[i:end]
+	}
+
+	i := 0
+
+	if s[0] == '*' {
+		result.tag = "*"
+		i++
+	} else {
+		result.tag = ident(0)
+		i += len(result.tag)
+	}
+
+	for i < len(s) {
+		prefix := s[i]
+		name := ident(i + 1)
+
+		if name == "" {
+			return cssCompound{}, ErrCSSParse{What: "selector", Value: s}
+		}
+
+		switch prefix {
+		case '.':
+			result.classes = append(result.classes, name)
+		case '#':
+			result.id = name
+		case ':':
+			if name == "root" {
+				result.tag = MainTag
+				i += 1 + len(name)
+
+				continue
+			}
+
+			state, ok := cssPseudoStates[name]
+			if !ok {
+				return cssCompound{}, ErrCSSParse{What: "pseudo-class", Value: name}
+			}
+
+			result.state = state
+		default:
+			return cssCompound{}, ErrCSSParse{What: "selector (unsupported character)", Value: s}
+		}
+
+		i += 1 + len(name)
+	}
+
+	return result, nil
+}
+
+func (s cssSelector) String() string {
+	parts := make([]string, len(s))
+	for i, c := range s {
+		parts[i] = c.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// specificity is computed like in CSS: IDs > classes and pseudo-classes > tags.
+func (s cssSelector) specificity() int {
+	var ids, classes, tags int
+
+	for _, c := range s {
+		if c.id != "" {
+			ids++
+		}
+
+		classes += len(c.classes)
+		if c.state != cssStateNone {
+			classes++
+		}
+
+		if c.tag != "" && c.tag != "*" {
+			tags++
+		}
+	}
+
+	return ids<<20 | classes<<10 | tags
+}
+
+// matches reports whether selector matches the last element of path.
+// path[0] is the root, the other elements are its descendants.
+func (s cssSelector) matches(path []*cssElement) bool {
+	if len(path) == 0 || !s[len(s)-1].matches(path[len(path)-1]) {
+		return false
+	}
+
+	ancestor := len(path) - 2
+
+	for i := len(s) - 2; i >= 0; i-- {
+		for ancestor >= 0 && !s[i].matches(path[ancestor]) {
+			ancestor--
+		}
+
+		if ancestor < 0 {
+			return false
+		}
+
+		ancestor--
+	}
+
+	return true
+}
+
+// references reports whether tag is used anywhere in the selector.
+func (s cssSelector) references(tag string) bool {
+	return slices.ContainsFunc(s, func(c cssCompound) bool {
+		return c.tag == tag
+	})
+}
+
+// cssRule is a selector with its style.
+type cssRule struct {
+	selector cssSelector
+	style    *StyleSetter
+}
+
+// cssElement is a widget as seen by CSS selectors.
+type cssElement struct {
+	widget   Widget
+	tag      string
+	id       string
+	classes  []string
+	disabled bool
+}
+
+// cssAttributes is embedded by widgets that can be targeted by .class and #id selectors.
+type cssAttributes struct {
+	cssClasses []string
+	cssID      string
+}
+
+func (a *cssAttributes) cssAttrs() *cssAttributes {
+	return a
+}
+
+func (a *cssAttributes) addClasses(classes []string) {
+	for _, class := range classes {
+		a.cssClasses = append(a.cssClasses, strings.Fields(class)...)
+	}
+}
+
+// newCSSElement describes w placed in parent (parent may be nil).
+func newCSSElement(w Widget, parent *cssElement) *cssElement {
+	result := &cssElement{
+		widget: w,
+		tag:    cssKind(w),
+	}
+
+	if a, ok := w.(interface{ cssAttrs() *cssAttributes }); ok {
+		attrs := a.cssAttrs()
+		result.id = attrs.cssID
+		result.classes = attrs.cssClasses
+	}
+
+	switch typed := w.(type) {
+	case *CSSTagWidget:
+		result.tag = typed.tag
+	case *ButtonWidget:
+		result.disabled = typed.disabled
+	case *StyleSetter:
+		result.disabled = typed.disabled
+	}
+
+	if parent != nil && parent.disabled {
+		result.disabled = true
+	}
+
+	return result
+}
+
+var cssKinds sync.Map // map[reflect.Type]string
+
+// cssKind returns a tag used to match w by CSS type selectors.
+// It is w's type name without "Widget" suffix in kebab-case
+// (e.g. ButtonWidget -> button, InputTextWidget -> input-text).
+func cssKind(w Widget) string {
+	t := reflect.TypeOf(w)
+	if kind, ok := cssKinds.Load(t); ok {
+		return kind.(string) //nolint:forcetypeassert // cssKinds stores strings only
+	}
+
+	name := t.Name()
+	if t.Kind() == reflect.Pointer {
+		name = t.Elem().Name()
+	}
+
+	name = strings.TrimSuffix(name, "Widget")
+
+	var sb strings.Builder
+
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune('-')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	kind := sb.String()
+	cssKinds.Store(t, kind)
+
+	return kind
+}
+
+// cssKindColors maps a widget kind to the color which background-color refers to in :hover and :active rules.
+var cssKindColors = map[string]StyleColorID{
+	"button":                 StyleColorButton,
+	"small-button":           StyleColorButton,
+	"arrow-button":           StyleColorButton,
+	"image-button":           StyleColorButton,
+	"image-button-with-rgba": StyleColorButton,
+	"checkbox":               StyleColorFrameBg,
+	"radio-button":           StyleColorFrameBg,
+	"input-text":             StyleColorFrameBg,
+	"input-text-multiline":   StyleColorFrameBg,
+	"input-int":              StyleColorFrameBg,
+	"input-float":            StyleColorFrameBg,
+	"slider-int":             StyleColorFrameBg,
+	"v-slider-int":           StyleColorFrameBg,
+	"slider-float":           StyleColorFrameBg,
+	"drag-int":               StyleColorFrameBg,
+	"drag-float":             StyleColorFrameBg,
+	"combo":                  StyleColorFrameBg,
+	"combo-custom":           StyleColorFrameBg,
+	"color-edit":             StyleColorFrameBg,
+	"selectable":             StyleColorHeader,
+	"tree-node":              StyleColorHeader,
+	"menu":                   StyleColorHeader,
+	"menu-item":              StyleColorHeader,
+	"tab-bar":                StyleColorTab,
+}
+
+// cssStateColors maps colors to their :hover and :active variants.
+var cssStateColors = map[cssPseudoState]map[StyleColorID]StyleColorID{
+	cssStateHover: {
+		StyleColorButton:        StyleColorButtonHovered,
+		StyleColorFrameBg:       StyleColorFrameBgHovered,
+		StyleColorHeader:        StyleColorHeaderHovered,
+		StyleColorTab:           StyleColorTabHovered,
+		StyleColorScrollbarGrab: StyleColorScrollbarGrabHovered,
+		StyleColorSeparator:     StyleColorSeparatorHovered,
+		StyleColorResizeGrip:    StyleColorResizeGripHovered,
+		StyleColorPlotLines:     StyleColorPlotLinesHovered,
+		StyleColorPlotHistogram: StyleColorPlotHistogramHovered,
+	},
+	cssStateActive: {
+		StyleColorButton:        StyleColorButtonActive,
+		StyleColorFrameBg:       StyleColorFrameBgActive,
+		StyleColorHeader:        StyleColorHeaderActive,
+		StyleColorTab:           StyleColorTabActive,
+		StyleColorScrollbarGrab: StyleColorScrollbarGrabActive,
+		StyleColorSeparator:     StyleColorSeparatorActive,
+		StyleColorResizeGrip:    StyleColorResizeGripActive,
+		StyleColorSliderGrab:    StyleColorSliderGrabActive,
+	},
+}
+
+// checkStateDeclarations returns an error if a :hover or :active rule declares a property that cannot be
+// applied by cssStateStyle (only colors having a state variant and background-color are supported).
+func (c cssSelector) checkStateDeclarations(declarations [][2]string) error {
+	subject := c[len(c)-1]
+
+	variants, ok := cssStateColors[subject.state]
+	if !ok {
+		return nil
+	}
+
+	for _, declaration := range declarations {
+		name := declaration[0]
+		if strings.HasPrefix(name, "--") {
+			continue
+		}
+
+		id, err := StyleColorIDString(name)
+		if err == nil {
+			if _, ok := variants[id]; ok {
+				continue
+			}
+
+			_, kindColor := cssKindColors[subject.tag]
+			if id == StyleColorWindowBg && (kindColor || subject.tag == "" || subject.tag == "*") {
+				continue
+			}
+		}
+
+		return ErrCSSParse{What: "declaration (not supported in :hover and :active rules)", Value: name}
+	}
+
+	return nil
+}
+
+// cssStateStyle converts style of a :hover or :active rule matched by an element of kind tag.
+// imgui chooses hovered/active colors by itself, so colors are moved to their state variants
+// (e.g. button-color -> button-hovered-color, background-color of a button -> button-hovered-color).
+// Other properties are rejected by checkStateDeclarations; background-color is dropped
+// only if the rule (e.g. .danger:hover) matches a widget without a background color.
+func cssStateStyle(style *StyleSetter, tag string, state cssPseudoState) *StyleSetter {
+	variants := cssStateColors[state]
+	result := Style()
+
+	for id, col := range style.colors {
+		if base, ok := cssKindColors[tag]; ok && id == StyleColorWindowBg {
+			id = base
+		}
+
+		if variant, ok := variants[id]; ok {
+			result.colors[variant] = col
+		}
+	}
+
+	return result
+}
+
+// resolve returns style of all rules matching the last element of path (or nil if none).
+// Rules are applied in order of their specificity (and position in stylesheet for equal specificity).
+func (c *CSSStylesheet) resolve(path []*cssElement) *StyleSetter {
+	if len(path) == 0 {
+		return nil
+	}
+
+	var matched []*cssRule
+
+	for _, rule := range c.rules {
+		if rule.selector.matches(path) {
+			matched = append(matched, rule)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(matched, func(a, b *cssRule) int {
+		return a.selector.specificity() - b.selector.specificity()
+	})
+
+	element := path[len(path)-1]
+	result := Style()
+
+	for _, rule := range matched {
+		switch state := rule.selector[len(rule.selector)-1].state; state {
+		case cssStateHover, cssStateActive:
+			result.Add(cssStateStyle(rule.style, element.tag, state))
+		default:
+			result.Add(rule.style)
+		}
+	}
+
+	return result
+}
+
+// hasSelectors reports whether there are rules that could be matched by widgets
+// (MainTag is applied by MasterWindow).
+func (c *CSSStylesheet) hasSelectors() bool {
+	return slices.ContainsFunc(c.rules, func(r *cssRule) bool {
+		return len(r.selector) != 1 || r.selector[0].tag != MainTag
+	})
+}
+
+// applyCSS appends w to Context's CSS path and pushes the style of rules matching it.
+// The returned function reverts that and must be called after w is built.
+func applyCSS(w Widget) (pop func()) {
+	stylesheet := Context.cssStylesheet
+	if stylesheet == nil || !stylesheet.hasSelectors() {
+		return func() {}
+	}
+
+	var parent *cssElement
+	if l := len(Context.cssPath); l > 0 {
+		parent = Context.cssPath[l-1]
+	}
+
+	Context.cssPath = append(Context.cssPath, newCSSElement(w, parent))
+	depth := len(Context.cssPath)
+
+	var style *StyleSetter
+
+	// CSSTagWidget applies its stylesheet by itself
+	if _, isTag := w.(*CSSTagWidget); !isTag {
+		style = stylesheet.resolve(Context.cssPath)
+	}
+
+	if style != nil {
+		style.Push()
+	}
+
+	return func() {
+		if style != nil {
+			style.Pop()
+		}
+
+		Context.cssPath = Context.cssPath[:depth-1]
+	}
+}
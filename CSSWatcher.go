@@ -0,0 +1,163 @@
+package giu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/image/colornames"
+)
+
+// CSSWatchInterval is an interval in which files watched by WatchCSSStylesheet are checked for changes.
+var CSSWatchInterval = 500 * time.Millisecond
+
+// cssWatcher polls a CSS file and parses it when it changes.
+// Parsed stylesheet is swapped in by MasterWindow at the beginning of a frame (see apply).
+type cssWatcher struct {
+	fileWatcher
+
+	m *sync.Mutex
+	// pending is a stylesheet parsed, but not applied yet
+	pending *CSSStylesheet
+	// err is the last reload error (nil if the last reload succeeded)
+	err error
+	// errDismissed is set when user closes the error overlay
+	errDismissed bool
+
+	stop chan struct{}
+}
+
+// WatchCSSStylesheet parses CSS file at path and sets it as Context's stylesheet (see SetCSSStylesheet).
+// Then the file is polled (see CSSWatchInterval) and re-parsed whenever it changes on disk.
+// New stylesheet is applied at the beginning of the next frame.
+// If it fails to parse, the last good stylesheet stays active and the error is shown in an overlay window.
+//
+// Only one file is watched at once - calling it again stops watching the previous file.
+// NOTE: the returned error refers to the initial load only.
+func (c *GIUContext) WatchCSSStylesheet(path string) error {
+	c.StopWatchingCSSStylesheet()
+
+	w := &cssWatcher{
+		fileWatcher: fileWatcher{path: path},
+		m:           &sync.Mutex{},
+		stop:        make(chan struct{}),
+	}
+
+	if _, err := w.changed(); err != nil {
+		return fmt.Errorf("watching CSS stylesheet: %w", err)
+	}
+
+	ss, err := w.load()
+	if err != nil {
+		return err
+	}
+
+	c.SetCSSStylesheet(ss)
+	c.cssWatcher = w
+
+	go w.poll(CSSWatchInterval, w.stop, w.reload)
+
+	return nil
+}
+
+// StopWatchingCSSStylesheet stops watching the file set by WatchCSSStylesheet (noop if nothing is watched).
+// The current stylesheet stays active.
+func (c *GIUContext) StopWatchingCSSStylesheet() {
+	if c.cssWatcher == nil {
+		return
+	}
+
+	close(c.cssWatcher.stop)
+	c.cssWatcher = nil
+}
+
+func (w *cssWatcher) load() (*CSSStylesheet, error) {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CSS stylesheet: %w", err)
+	}
+
+	ss := CSS()
+	if err := ss.Parse(data); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", w.path, err)
+	}
+
+	return ss, nil
+}
+
+// reload parses the changed file. The stylesheet is applied by apply.
+func (w *cssWatcher) reload() {
+	ss, err := w.load()
+
+	w.m.Lock()
+
+	if err == nil {
+		w.pending = ss
+	}
+
+	w.err = err
+	w.errDismissed = false
+
+	w.m.Unlock()
+
+	Update()
+}
+
+// apply swaps the pending stylesheet in. Must be called between frames.
+func (w *cssWatcher) apply() {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.pending != nil {
+		Context.SetCSSStylesheet(w.pending)
+		w.pending = nil
+	}
+}
+
+// buildErrorOverlay shows the last reload error (if any) in a window.
+func (w *cssWatcher) buildErrorOverlay() {
+	w.m.Lock()
+	err, dismissed := w.err, w.errDismissed
+	w.m.Unlock()
+
+	if err == nil || dismissed {
+		return
+	}
+
+	open := true
+	details := Layout{
+		Label(err.Error()).Wrapped(true),
+	}
+
+	var parseErr ErrCSSParse
+	if errors.As(err, &parseErr) {
+		details = append(details,
+			Separator(),
+			Labelf("What: %s", parseErr.What),
+			Labelf("Value: %q", parseErr.Value),
+		)
+
+		if parseErr.Detail != nil {
+			details = append(details, Labelf("Detail: %v", parseErr.Detail).Wrapped(true))
+		}
+	}
+
+	Window("CSS stylesheet error##giuCSSWatcher").
+		IsOpen(&open).
+		Flags(WindowFlagsAlwaysAutoResize|WindowFlagsNoCollapse|WindowFlagsNoSavedSettings).
+		Layout(
+			Style().SetColor(StyleColorText, colornames.Orangered).To(details...),
+			Label("The last good stylesheet is still active."),
+			Button("Dismiss").OnClick(func() {
+				open = false
+			}),
+		)
+
+	if !open {
+		w.m.Lock()
+		w.errDismissed = true
+		w.m.Unlock()
+	}
+}
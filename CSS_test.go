@@ -0,0 +1,194 @@
+package giu
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+func Test_parseCSSSelector(t *testing.T) {
+	cases := []struct {
+		selector    string
+		expected    string
+		specificity int
+		err         bool
+	}{
+		{selector: "button", expected: "button", specificity: 1},
+		{selector: " toolbar   button.danger:hover ", expected: "toolbar button.danger:hover", specificity: 2<<10 | 2},
+		{selector: "#save", expected: "#save", specificity: 1 << 20},
+		{selector: "*.a.b", expected: "*.a.b", specificity: 2 << 10},
+		{selector: "button:hover label", err: true},
+		{selector: "toolbar > button", err: true},
+		{selector: "button:focus", err: true},
+		{selector: ".", err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			selector, err := parseCSSSelector(tc.selector)
+			if tc.err {
+				assert.Error(t, err, "expected parsing error")
+				return
+			}
+
+			if assert.NoError(t, err, "unexpected parsing error") {
+				assert.Equal(t, tc.expected, selector.String(), "unexpected normalized selector")
+				assert.Equal(t, tc.specificity, selector.specificity(), "unexpected specificity")
+			}
+		})
+	}
+}
+
+func Test_cssSelector_matches(t *testing.T) {
+	// NOTE: widgets are created without constructors which need Context
+	toolbar := newCSSElement(&CSSTagWidget{tag: "toolbar"}, nil)
+	row := newCSSElement(&RowWidget{}, toolbar)
+	button := newCSSElement((&ButtonWidget{}).Class("danger big").CSSID("save").Disabled(true), row)
+	path := []*cssElement{toolbar, row, button}
+
+	cases := []struct {
+		selector string
+		matches  bool
+	}{
+		{"button", true},
+		{"toolbar button", true},
+		{"toolbar row button.danger", true},
+		{"button.big.danger#save", true},
+		{"button:disabled", true},
+		{"row toolbar button", false},
+		{"label button", false},
+		{"button.small", false},
+		{"toolbar", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			selector, err := parseCSSSelector(tc.selector)
+			if assert.NoError(t, err, "unexpected parsing error") {
+				assert.Equal(t, tc.matches, selector.matches(path), "unexpected match result")
+			}
+		})
+	}
+}
+
+func Test_cssKind(t *testing.T) {
+	assert.Equal(t, "button", cssKind(&ButtonWidget{}), "unexpected kind")
+	assert.Equal(t, "input-text-multiline", cssKind(&InputTextMultilineWidget{}), "unexpected kind")
+	assert.Equal(t, "style-setter", cssKind(Style()), "unexpected kind")
+}
+
+func TestCSSStylesheet_Parse(t *testing.T) {
+	ss := CSS()
+	err := ss.Parse([]byte(`
+/* comment */
+button, .danger {
+	button-color: red;
+}
+
+button:hover {
+	background-color: blue;
+}
+
+.danger {
+	alpha: 0.5;
+}
+`))
+
+	if !assert.NoError(t, err, "unexpected parsing error") {
+		return
+	}
+
+	assert.True(t, ss.HasTag("button"), "button rule missing")
+	assert.Equal(t, float32(0.5), ss.GetTag(".danger").GetStyleFloat(StyleVarAlpha), "rules with the same selector weren't merged")
+
+	path := []*cssElement{newCSSElement(&ButtonWidget{}, nil)}
+	style := ss.resolve(path)
+
+	if assert.NotNil(t, style, "no style resolved") {
+		assert.Equal(t, colornames.Red, style.GetColor(StyleColorButton), "unexpected button color")
+		assert.Equal(t, colornames.Blue, style.GetColor(StyleColorButtonHovered), ":hover not resolved against widget kind")
+	}
+
+	err = CSS().Parse([]byte("button { color: red; "))
+	if assert.Error(t, err, "unclosed block accepted") {
+		assert.Contains(t, err.Error(), "missing }", "unexpected error")
+	}
+
+	for _, input := range []string{
+		"button:hover { color: green; }",
+		"button:active { frame-rounding: 4; }",
+		".danger:hover { font-size: 20; }",
+		"text:hover { background-color: red; }",
+	} {
+		assert.Error(t, CSS().Parse([]byte(input)), "unsupported property of a state rule accepted: %s", input)
+	}
+
+	assert.NoError(t, CSS().Parse([]byte(".danger:hover { background-color: red; button-color: blue; }")), "supported properties of a state rule rejected")
+}
+
+func Test_cssWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "style.css")
+	assert.NoError(t, os.WriteFile(path, []byte("main { alpha: 0.5; }"), 0o600), "writing stylesheet")
+
+	w := &cssWatcher{fileWatcher: fileWatcher{path: path}, m: &sync.Mutex{}}
+
+	changed, err := w.changed()
+	assert.NoError(t, err, "checking file")
+	assert.True(t, changed, "first check should report change")
+
+	changed, _ = w.changed()
+	assert.False(t, changed, "file didn't change")
+
+	ss, err := w.load()
+	if assert.NoError(t, err, "loading stylesheet") {
+		assert.True(t, ss.HasTag(MainTag), "main tag missing")
+	}
+
+	assert.NoError(t, os.WriteFile(path, []byte("main { not-a-style: 1; }"), 0o600), "writing stylesheet")
+
+	changed, _ = w.changed()
+	assert.True(t, changed, "change not detected")
+
+	_, err = w.load()
+	assert.ErrorAs(t, err, &ErrCSSParse{}, "expected CSS parsing error")
+}
+
+func TestCSSStylesheet_Parse_properties(t *testing.T) {
+	ss := CSS()
+	err := ss.Parse([]byte(`
+:root {
+	--accent: red;
+	--padding: 4, var(--half, 2);
+}
+
+button {
+	button-color: var(--accent);
+	frame-padding: var(--padding);
+	font-size: 20px;
+	disabled: true;
+	plot-border-size: 3;
+	plot-legend-text: var(--missing, blue);
+}
+`))
+
+	if !assert.NoError(t, err, "unexpected parsing error") {
+		return
+	}
+
+	button := ss.GetTag("button")
+	assert.Equal(t, colornames.Red, button.GetColor(StyleColorButton), "variable not resolved")
+
+	x, y := button.GetStyle(StyleVarFramePadding)
+	assert.Equal(t, [2]float32{4, 2}, [2]float32{x, y}, "nested variable fallback not resolved")
+	assert.Equal(t, float32(20), button.fontSize, "unexpected font size")
+	assert.True(t, button.disabled, "disabled not set")
+	assert.Equal(t, float32(3), button.GetPlotStyleFloat(StylePlotVarPlotBorderSize), "plot style var not set")
+	assert.Equal(t, ToVec4Color(colornames.Blue), ToVec4Color(button.GetPlotColor(StylePlotColorLegendText)), "plot color not set")
+
+	assert.Error(t, CSS().Parse([]byte("button { button-color: var(--undefined); }")), "undefined variable accepted")
+	assert.Error(t, CSS().Parse([]byte(":root { --a: var(--b); --b: var(--a); } button { alpha: var(--a); }")), "cyclic variable accepted")
+}
@@ -18,6 +18,8 @@ type ButtonWidget struct {
 	height   float32
 	disabled bool
 	onClick  func()
+
+	cssAttributes
 }
 
 // Button creates a new button widget.
@@ -33,7 +35,7 @@ func Button(label string) *ButtonWidget {
 // Buttonf creates button with formatted label
 // NOTE: works like fmt.Sprintf (see `go doc fmt`).
 func Buttonf(format string, args ...any) *ButtonWidget {
-	return Button(fmt.Sprintf(format, args...))
+	return Button(Context.PrepareStringf(format, args...))
 }
 
 // OnClick sets callback called when button is clicked
@@ -62,6 +64,18 @@ func (b *ButtonWidget) ID(id ID) *ButtonWidget {
 	return b
 }
 
+// Class adds CSS classes to the button (matched by .class selectors).
+func (b *ButtonWidget) Class(classes ...string) *ButtonWidget {
+	b.addClasses(classes)
+	return b
+}
+
+// CSSID sets CSS ID of the button (matched by #id selectors).
+func (b *ButtonWidget) CSSID(id string) *ButtonWidget {
+	b.cssID = id
+	return b
+}
+
 // Build implements Widget interface.
 func (b *ButtonWidget) Build() {
 	if b.disabled {
@@ -82,6 +96,8 @@ type ArrowButtonWidget struct {
 	id      ID
 	dir     Direction
 	onClick func()
+
+	cssAttributes
 }
 
 // ArrowButton creates ArrowButtonWidget.
@@ -105,6 +121,18 @@ func (b *ArrowButtonWidget) ID(id ID) *ArrowButtonWidget {
 	return b
 }
 
+// Class adds CSS classes to the arrow button (matched by .class selectors).
+func (b *ArrowButtonWidget) Class(classes ...string) *ArrowButtonWidget {
+	b.addClasses(classes)
+	return b
+}
+
+// CSSID sets CSS ID of the arrow button (matched by #id selectors).
+func (b *ArrowButtonWidget) CSSID(id string) *ArrowButtonWidget {
+	b.cssID = id
+	return b
+}
+
 // Build implements Widget interface.
 func (b *ArrowButtonWidget) Build() {
 	if imgui.ArrowButton(b.id.String(), imgui.Dir(b.dir)) && b.onClick != nil {
@@ -118,6 +146,8 @@ var _ Widget = &SmallButtonWidget{}
 type SmallButtonWidget struct {
 	id      ID
 	onClick func()
+
+	cssAttributes
 }
 
 // SmallButton constructs a new small button widget.
@@ -131,7 +161,7 @@ func SmallButton(id string) *SmallButtonWidget {
 // SmallButtonf allows to set formatted label for small button.
 // It calls SmallButton(fmt.Sprintf(label, args...)).
 func SmallButtonf(format string, args ...any) *SmallButtonWidget {
-	return SmallButton(fmt.Sprintf(format, args...))
+	return SmallButton(Context.PrepareStringf(format, args...))
 }
 
 // OnClick adds OnClick event.
@@ -140,6 +170,18 @@ func (b *SmallButtonWidget) OnClick(onClick func()) *SmallButtonWidget {
 	return b
 }
 
+// Class adds CSS classes to the small button (matched by .class selectors).
+func (b *SmallButtonWidget) Class(classes ...string) *SmallButtonWidget {
+	b.addClasses(classes)
+	return b
+}
+
+// CSSID sets CSS ID of the small button (matched by #id selectors).
+func (b *SmallButtonWidget) CSSID(id string) *SmallButtonWidget {
+	b.cssID = id
+	return b
+}
+
 // Build implements Widget interface.
 func (b *SmallButtonWidget) Build() {
 	if imgui.SmallButton(Context.PrepareString(b.id.String())) && b.onClick != nil {
@@ -208,6 +250,8 @@ type ImageButtonWidget struct {
 	tintColor    color.Color
 	onClick      func()
 	id           ID
+
+	cssAttributes
 }
 
 // ImageButton  constructs image button widget.
@@ -232,6 +276,18 @@ func (b *ImageButtonWidget) ID(id ID) *ImageButtonWidget {
 	return b
 }
 
+// Class adds CSS classes to the image button (matched by .class selectors).
+func (b *ImageButtonWidget) Class(classes ...string) *ImageButtonWidget {
+	b.addClasses(classes)
+	return b
+}
+
+// CSSID sets CSS ID of the image button (matched by #id selectors).
+func (b *ImageButtonWidget) CSSID(id string) *ImageButtonWidget {
+	b.cssID = id
+	return b
+}
+
 // Build implements Widget interface.
 func (b *ImageButtonWidget) Build() {
 	if b.texture == nil || b.texture.tex == nil {
@@ -369,6 +425,12 @@ type CheckboxWidget struct {
 	text     ID
 	selected *bool
 	onChange func()
+	undo     *UndoStack
+
+	// bind writes value back to the bound Observable (see CheckboxBound)
+	bind func()
+
+	cssAttributes
 }
 
 // Checkbox creates a new CheckboxWidget.
@@ -392,9 +454,39 @@ func (c *CheckboxWidget) OnChange(onChange func()) *CheckboxWidget {
 	return c
 }
 
+// UndoStack sets stack recording changes of the checkbox's value (see UndoStack).
+func (c *CheckboxWidget) UndoStack(stack *UndoStack) *CheckboxWidget {
+	c.undo = stack
+	return c
+}
+
+// Class adds CSS classes to the checkbox (matched by .class selectors).
+func (c *CheckboxWidget) Class(classes ...string) *CheckboxWidget {
+	c.addClasses(classes)
+	return c
+}
+
+// CSSID sets CSS ID of the checkbox (matched by #id selectors).
+func (c *CheckboxWidget) CSSID(id string) *CheckboxWidget {
+	c.cssID = id
+	return c
+}
+
 // Build implements Widget interface.
 func (c *CheckboxWidget) Build() {
-	if imgui.Checkbox(Context.PrepareString(c.text.String()), c.selected) && c.onChange != nil {
+	before := *c.selected
+	isChanged := imgui.Checkbox(Context.PrepareString(c.text.String()), c.selected)
+	trackEdit(c.undo, c.text, c.selected, before, c.bind)
+
+	if !isChanged {
+		return
+	}
+
+	if c.bind != nil {
+		c.bind()
+	}
+
+	if c.onChange != nil {
 		c.onChange()
 	}
 }
@@ -408,6 +500,8 @@ type RadioButtonWidget struct {
 	text     ID
 	active   bool
 	onChange func()
+
+	cssAttributes
 }
 
 // RadioButton creates a radio button.
@@ -425,6 +519,18 @@ func (r *RadioButtonWidget) OnChange(onChange func()) *RadioButtonWidget {
 	return r
 }
 
+// Class adds CSS classes to the radio button (matched by .class selectors).
+func (r *RadioButtonWidget) Class(classes ...string) *RadioButtonWidget {
+	r.addClasses(classes)
+	return r
+}
+
+// CSSID sets CSS ID of the radio button (matched by #id selectors).
+func (r *RadioButtonWidget) CSSID(id string) *RadioButtonWidget {
+	r.cssID = id
+	return r
+}
+
 // Build implements Widget interface.
 func (r *RadioButtonWidget) Build() {
 	if imgui.RadioButtonBool(Context.PrepareString(r.text.String()), r.active) && r.onChange != nil {
@@ -444,6 +550,8 @@ type SelectableWidget struct {
 	height   float32
 	onClick  func()
 	onDClick func()
+
+	cssAttributes
 }
 
 // Selectable constructs a selectable widget.
@@ -460,7 +568,7 @@ func Selectable(label string) *SelectableWidget {
 
 // Selectablef creates a selectable widget with formatted label.
 func Selectablef(format string, args ...any) *SelectableWidget {
-	return Selectable(fmt.Sprintf(format, args...))
+	return Selectable(Context.PrepareStringf(format, args...))
 }
 
 // Selected sets if selectable widget is selected.
@@ -495,6 +603,18 @@ func (s *SelectableWidget) OnDClick(onDClick func()) *SelectableWidget {
 	return s
 }
 
+// Class adds CSS classes to the selectable (matched by .class selectors).
+func (s *SelectableWidget) Class(classes ...string) *SelectableWidget {
+	s.addClasses(classes)
+	return s
+}
+
+// CSSID sets CSS ID of the selectable (matched by #id selectors).
+func (s *SelectableWidget) CSSID(id string) *SelectableWidget {
+	s.cssID = id
+	return s
+}
+
 // Build implements Widget interface.
 func (s *SelectableWidget) Build() {
 	// If onDClick is set, check flags and set related flag when necessary
@@ -522,6 +642,8 @@ type TreeNodeWidget struct {
 	layout       Layout
 	event        func()
 	eventHandler *EventHandler
+
+	cssAttributes
 }
 
 // TreeNode creates a new tree node widget.
@@ -536,7 +658,7 @@ func TreeNode(label string) *TreeNodeWidget {
 
 // TreeNodef adds TreeNode with formatted label.
 func TreeNodef(format string, args ...any) *TreeNodeWidget {
-	return TreeNode(fmt.Sprintf(format, args...))
+	return TreeNode(Context.PrepareStringf(format, args...))
 }
 
 // Flags sets flags.
@@ -566,6 +688,18 @@ func (t *TreeNodeWidget) Layout(widgets ...Widget) *TreeNodeWidget {
 	return t
 }
 
+// Class adds CSS classes to the tree node (matched by .class selectors).
+func (t *TreeNodeWidget) Class(classes ...string) *TreeNodeWidget {
+	t.addClasses(classes)
+	return t
+}
+
+// CSSID sets CSS ID of the tree node (matched by #id selectors).
+func (t *TreeNodeWidget) CSSID(id string) *TreeNodeWidget {
+	t.cssID = id
+	return t
+}
+
 // Build implements Widget interface.
 func (t *TreeNodeWidget) Build() {
 	open := imgui.TreeNodeExStrV(Context.PrepareString(t.label), imgui.TreeNodeFlags(t.flags))
@@ -604,7 +738,7 @@ func Link(text string) *LinkWidget {
 
 // Linkf allows to add formatted link.
 func Linkf(format string, args ...any) *LinkWidget {
-	return Link(fmt.Sprintf(format, args...))
+	return Link(Context.PrepareStringf(format, args...))
 }
 
 // ID allows to manually set widget's id (in this case - text). Baypasses GenAutoID mechanism in cae this is needed.
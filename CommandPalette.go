@@ -0,0 +1,364 @@
+package giu
+
+import (
+	"cmp"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/sahilm/fuzzy"
+	"golang.org/x/image/colornames"
+)
+
+// commandPaletteBinding is the name of the key binding opening CommandPalette (it may be changed by a Keymap).
+const commandPaletteBinding = "giu.commandPalette"
+
+// paletteEntry is a command listed by CommandPalette.
+type paletteEntry struct {
+	command PaletteCommand
+	// label is "Category: Title"
+	label string
+	// matched are byte indexes of label characters matching the query
+	matched []int
+}
+
+// paletteLabel returns the text of c shown by CommandPalette.
+func paletteLabel(c PaletteCommand) string {
+	if c.Category == "" {
+		return c.title()
+	}
+
+	return c.Category + ": " + c.title()
+}
+
+// rankCommands returns commands matching query (fuzzy), the best matches first.
+// Recently used commands (see commandRegistry.recent) go first if query is empty and win ties otherwise.
+func rankCommands(commands []PaletteCommand, query string, recent []string) []paletteEntry {
+	recency := func(name string) int {
+		if idx := slices.Index(recent, name); idx >= 0 {
+			return idx
+		}
+
+		return len(recent)
+	}
+
+	labels := make([]string, len(commands))
+	for i, c := range commands {
+		labels[i] = paletteLabel(c)
+	}
+
+	if strings.TrimSpace(query) == "" {
+		result := make([]paletteEntry, len(commands))
+		for i, c := range commands {
+			result[i] = paletteEntry{command: c, label: labels[i]}
+		}
+
+		slices.SortStableFunc(result, func(a, b paletteEntry) int {
+			return cmp.Or(
+				cmp.Compare(recency(a.command.Name), recency(b.command.Name)),
+				strings.Compare(a.label, b.label),
+			)
+		})
+
+		return result
+	}
+
+	matches := fuzzy.Find(query, labels)
+	slices.SortStableFunc(matches, func(a, b fuzzy.Match) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(recency(commands[a.Index].Name), recency(commands[b.Index].Name)),
+		)
+	})
+
+	result := make([]paletteEntry, len(matches))
+	for i, m := range matches {
+		result[i] = paletteEntry{command: commands[m.Index], label: m.Str, matched: m.MatchedIndexes}
+	}
+
+	return result
+}
+
+var _ Disposable = &commandPaletteState{}
+
+type commandPaletteState struct {
+	// open is set by the key binding (outside of the frame)
+	open     bool
+	query    string
+	selected int
+	focus    bool
+	// keys are the keys of the registered key binding (nil if it was not registered)
+	keys []KeyStroke
+}
+
+// Dispose implements Disposable interface.
+func (s *commandPaletteState) Dispose() {
+	// noop
+}
+
+var _ Widget = &CommandPaletteWidget{}
+
+// CommandPaletteWidget is a modal listing commands (see RegisterCommands and named key bindings)
+// filtered by fuzzy search. Matched characters are highlighted and recently used commands are listed first.
+// Up/Down, PageUp/PageDown select a command, Enter runs it and Escape closes the palette.
+type CommandPaletteWidget struct {
+	id         ID
+	keys       []KeyStroke
+	width      float32
+	maxResults int
+	highlight  color.Color
+}
+
+// CommandPalette creates a new CommandPaletteWidget opened by Ctrl+Shift+P. It is a modal, so build it
+// in the loop function (e.g. after windows):
+//
+//	giu.CommandPalette().Build()
+func CommandPalette() *CommandPaletteWidget {
+	return &CommandPaletteWidget{
+		id:         "##giuCommandPalette",
+		keys:       []KeyStroke{{KeyP, ModControl | ModShift}},
+		width:      500,
+		maxResults: 12,
+		highlight:  colornames.Orange,
+	}
+}
+
+// ID sets the id of the palette.
+func (p *CommandPaletteWidget) ID(id ID) *CommandPaletteWidget {
+	p.id = id
+	return p
+}
+
+// Shortcut sets keys opening the palette (nil disables them). It may be changed by a Keymap as "giu.commandPalette".
+func (p *CommandPaletteWidget) Shortcut(keys ...KeyStroke) *CommandPaletteWidget {
+	p.keys = keys
+	return p
+}
+
+// Size sets width of the palette.
+func (p *CommandPaletteWidget) Size(width float32) *CommandPaletteWidget {
+	p.width = width
+	return p
+}
+
+// MaxResults sets the number of commands visible without scrolling.
+func (p *CommandPaletteWidget) MaxResults(n int) *CommandPaletteWidget {
+	p.maxResults = n
+	return p
+}
+
+// HighlightColor sets color of matched characters.
+func (p *CommandPaletteWidget) HighlightColor(c color.Color) *CommandPaletteWidget {
+	p.highlight = c
+	return p
+}
+
+// Open opens the palette. It should be called on a widget with the same ID as the built one.
+func (p *CommandPaletteWidget) Open() {
+	p.getState().open = true
+
+	Update()
+}
+
+func (p *CommandPaletteWidget) getState() *commandPaletteState {
+	var state *commandPaletteState
+	if state = GetState[commandPaletteState](Context, p.id); state == nil {
+		state = &commandPaletteState{}
+		SetState(Context, p.id, state)
+	}
+
+	return state
+}
+
+// registerShortcut registers the key binding opening the palette (if keys changed).
+func (p *CommandPaletteWidget) registerShortcut(state *commandPaletteState) {
+	h := keyBindingHandler()
+	if h == nil || (state.keys != nil && slices.Equal(state.keys, p.keys)) {
+		return
+	}
+
+	h.UnregisterKeyBindings(commandPaletteBinding)
+
+	if len(p.keys) > 0 {
+		h.RegisterKeyBindings(KeyBinding{
+			Name: commandPaletteBinding,
+			Keys: p.keys,
+			Callback: func() {
+				state.open = true
+
+				Update()
+			},
+		})
+	}
+
+	state.keys = slices.Clone(p.keys)
+	if state.keys == nil {
+		state.keys = []KeyStroke{}
+	}
+}
+
+// Build implements Widget interface.
+func (p *CommandPaletteWidget) Build() {
+	state := p.getState()
+	p.registerShortcut(state)
+
+	name := p.id.String()
+
+	if state.open {
+		state.open = false
+		state.query, state.selected, state.focus = "", 0, true
+
+		OpenPopup(name)
+	}
+
+	viewport := imgui.MainViewport()
+	imgui.SetNextWindowPosV(
+		imgui.Vec2{X: viewport.Pos().X + viewport.Size().X/2, Y: viewport.Pos().Y + viewport.Size().Y/8},
+		imgui.CondAlways,
+		imgui.Vec2{X: 0.5, Y: 0},
+	)
+	// NOTE: 0 height makes the modal fit its content
+	imgui.SetNextWindowSize(imgui.Vec2{X: p.width, Y: 0})
+
+	PopupModal(name).
+		Flags(WindowFlagsNoTitleBar | WindowFlagsNoMove | WindowFlagsNoResize | WindowFlagsNoSavedSettings).
+		Layout(Custom(func() {
+			p.buildContent(state)
+		})).
+		Build()
+}
+
+func (p *CommandPaletteWidget) buildContent(state *commandPaletteState) {
+	if IsKeyPressed(KeyEscape) {
+		CloseCurrentPopup()
+		return
+	}
+
+	if state.focus {
+		imgui.SetKeyboardFocusHere()
+
+		state.focus = false
+	}
+
+	imgui.SetNextItemWidth(-1)
+
+	if imgui.InputTextWithHint("##giuCommandPaletteQuery", Context.PrepareString("Type a command"), &state.query, 0, nil) {
+		state.selected = 0
+	}
+
+	commands := slices.DeleteFunc(Commands(), func(c PaletteCommand) bool {
+		return c.Name == commandPaletteBinding
+	})
+
+	for i := range commands {
+		commands[i].Title = Context.PrepareString(commands[i].Title)
+		commands[i].Category = Context.PrepareString(commands[i].Category)
+	}
+
+	entries := rankCommands(commands, state.query, Context.commands.recent)
+	if len(entries) == 0 {
+		imgui.TextDisabled(Context.PrepareString("No matching commands"))
+		return
+	}
+
+	moved := p.handleKeys(state, len(entries))
+
+	if IsKeyPressed(KeyEnter) {
+		p.run(entries[state.selected].command)
+		return
+	}
+
+	keymap := commandKeymap()
+	lineHeight := imgui.TextLineHeightWithSpacing()
+	height := lineHeight * float32(min(len(entries), max(p.maxResults, 1)))
+
+	if imgui.BeginChildStrV("##giuCommandPaletteList", imgui.Vec2{X: 0, Y: height}, 0, 0) {
+		for i, e := range entries {
+			if p.buildEntry(i, e, keymap[e.command.Name], i == state.selected, moved) {
+				p.run(e.command)
+			}
+		}
+	}
+
+	imgui.EndChild()
+}
+
+// handleKeys moves the selection. It returns true if it was moved.
+func (p *CommandPaletteWidget) handleKeys(state *commandPaletteState, count int) bool {
+	page := max(p.maxResults-1, 1)
+	selected := state.selected
+
+	switch {
+	case IsKeyPressed(KeyDown):
+		selected = (selected + 1) % count
+	case IsKeyPressed(KeyUp):
+		selected = (selected - 1 + count) % count
+	case IsKeyPressed(KeyPageDown):
+		selected = min(selected+page, count-1)
+	case IsKeyPressed(KeyPageUp):
+		selected = max(selected-page, 0)
+	}
+
+	selected = min(max(selected, 0), count-1)
+	moved := selected != state.selected
+	state.selected = selected
+
+	return moved
+}
+
+// buildEntry builds a row of the list (shortcut is shown next to the command). It returns true if it was clicked.
+func (p *CommandPaletteWidget) buildEntry(idx int, e paletteEntry, shortcut string, selected, scroll bool) bool {
+	start, available := imgui.CursorPos(), imgui.ContentRegionAvail().X
+	clicked := imgui.SelectableBoolV(fmt.Sprintf("##giuCommand%d", idx), selected, 0, imgui.Vec2{})
+
+	if selected && scroll {
+		imgui.SetScrollHereYV(0.5)
+	}
+
+	imgui.SetCursorPos(start)
+
+	// draw runs of matched and not matched characters
+	runStart, runMatched := 0, false
+
+	for i := range e.label {
+		if matched := slices.Contains(e.matched, i); matched != runMatched {
+			p.drawRun(e.label[runStart:i], runMatched)
+			runStart, runMatched = i, matched
+		}
+	}
+
+	p.drawRun(e.label[runStart:], runMatched)
+
+	if shortcut == "" {
+		imgui.NewLine()
+		return clicked
+	}
+
+	width, _ := CalcTextSize(shortcut)
+	imgui.SetCursorPosX(start.X + available - width)
+	imgui.TextDisabled(shortcut)
+
+	return clicked
+}
+
+// drawRun draws text in the current line.
+func (p *CommandPaletteWidget) drawRun(text string, highlighted bool) {
+	if text == "" {
+		return
+	}
+
+	if highlighted {
+		imgui.PushStyleColorVec4(imgui.ColText, ToVec4Color(p.highlight))
+		defer imgui.PopStyleColor()
+	}
+
+	imgui.TextUnformatted(text)
+	imgui.SameLineV(0, 0)
+}
+
+func (p *CommandPaletteWidget) run(c PaletteCommand) {
+	CloseCurrentPopup()
+	RunCommand(c.Name)
+}
@@ -0,0 +1,39 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_CommandPalette(t *testing.T) {
+	var run []string
+
+	h := giutest.New(t, 600, 400)
+	giu.RegisterCommands(
+		giu.PaletteCommand{Name: "b", Title: "Second", Callback: func() { run = append(run, "b") }},
+		giu.PaletteCommand{Name: "a", Title: "First", Callback: func() { run = append(run, "a") }},
+	)
+
+	h.Run(1, func() giu.Layout {
+		return giu.Layout{
+			giu.CommandPalette(),
+		}
+	})
+
+	h.PressKey(giu.KeyP, giu.ModControl|giu.ModShift)
+	h.PressKey(giu.KeyEnter, giu.ModNone)
+	assert.Equal(t, []string{"a"}, run, "the first command wasn't run")
+
+	h.PressKey(giu.KeyP, giu.ModControl|giu.ModShift)
+	h.PressKey(giu.KeyDown, giu.ModNone)
+	h.PressKey(giu.KeyEnter, giu.ModNone)
+	assert.Equal(t, []string{"a", "b"}, run, "the selected command wasn't run")
+
+	h.PressKey(giu.KeyP, giu.ModControl|giu.ModShift)
+	h.PressKey(giu.KeyEnter, giu.ModNone)
+	assert.Equal(t, []string{"a", "b", "b"}, run, "the recently used command isn't the first one")
+}
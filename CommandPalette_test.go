@@ -0,0 +1,90 @@
+package giu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func entryNames(entries []paletteEntry) []string {
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.command.Name
+	}
+
+	return names
+}
+
+func Test_rankCommands(t *testing.T) {
+	commands := []PaletteCommand{
+		{Name: "file.save", Title: "Save", Category: "File"},
+		{Name: "file.saveAll", Title: "Save All", Category: "File"},
+		{Name: "view.zoomIn", Title: "Zoom In", Category: "View"},
+		{Name: "help.about"},
+	}
+
+	t.Run("empty query", func(t *testing.T) {
+		entries := rankCommands(commands, "", []string{"view.zoomIn"})
+		assert.Equal(t, []string{"view.zoomIn", "file.save", "file.saveAll", "help.about"}, entryNames(entries))
+		assert.Equal(t, "View: Zoom In", entries[0].label)
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		entries := rankCommands(commands, "fsa", nil)
+		assert.ElementsMatch(t, []string{"file.save", "file.saveAll"}, entryNames(entries))
+
+		for _, e := range entries {
+			matched := ""
+			for _, idx := range e.matched {
+				matched += string(e.label[idx])
+			}
+
+			assert.Equal(t, "fsa", strings.ToLower(matched), "wrong characters matched in %q", e.label)
+		}
+	})
+
+	t.Run("recent wins ties", func(t *testing.T) {
+		same := []PaletteCommand{{Name: "a.run", Title: "Run"}, {Name: "b.run", Title: "Run"}}
+
+		assert.Equal(t, []string{"a.run", "b.run"}, entryNames(rankCommands(same, "run", nil)))
+		assert.Equal(t, []string{"b.run", "a.run"}, entryNames(rankCommands(same, "run", []string{"b.run"})))
+	})
+}
+
+func Test_commandRegistry(t *testing.T) {
+	a := assert.New(t)
+	r := &commandRegistry{}
+	h := newInputHandler()
+
+	var run []string
+
+	r.add(
+		PaletteCommand{Name: "file.save", Callback: func() { run = append(run, "save") }},
+		PaletteCommand{Name: "file.open", Callback: func() { run = append(run, "open") }},
+	)
+	r.add(PaletteCommand{Name: "file.save", Title: "Save", Callback: func() { run = append(run, "save2") }})
+
+	h.RegisterKeyBindings(
+		KeyBinding{Name: "edit.copy", Keys: []KeyStroke{{KeyC, ModControl}}, Callback: func() { run = append(run, "copy") }},
+		KeyBinding{Name: "file.open", Keys: []KeyStroke{{KeyO, ModControl}}, Callback: func() { run = append(run, "open2") }},
+	)
+
+	all := r.all(h)
+	if a.Len(all, 3) {
+		a.Equal("Save", all[0].Title, "command wasn't replaced")
+		a.Equal("edit.copy", all[2].Name, "named key binding isn't a command")
+	}
+
+	a.True(r.run("file.save", h))
+	a.True(r.run("edit.copy", h))
+	a.True(r.run("file.open", h))
+	a.False(r.run("missing", h))
+	a.True(r.run("edit.copy", h))
+
+	a.Equal([]string{"save2", "copy", "open", "copy"}, run)
+	a.Equal([]string{"edit.copy", "file.open", "file.save"}, r.recent)
+
+	r.remove("file.open")
+	a.Len(r.all(nil), 1)
+}
@@ -0,0 +1,175 @@
+package giu
+
+import "slices"
+
+// maxRecentCommands is the number of recently run commands remembered for CommandPalette.
+const maxRecentCommands = 20
+
+// PaletteCommand is an action of the application listed by CommandPalette.
+type PaletteCommand struct {
+	// Name identifies the command, e.g. "file.save". It is also the name of its key bindings (see KeyBinding.Name).
+	Name string
+	// Title is shown by CommandPalette, e.g. "Save" (Name is shown if it is empty).
+	Title string
+	// Category (optional) groups commands, e.g. "File".
+	Category string
+	// Keys (optional) are the default keys of the command (a KeyBinding is registered for them).
+	Keys     []KeyStroke
+	Callback func()
+}
+
+// title returns Title or Name if Title is empty.
+func (c PaletteCommand) title() string {
+	if c.Title != "" {
+		return c.Title
+	}
+
+	return c.Name
+}
+
+// commandRegistry stores commands registered by RegisterCommands.
+type commandRegistry struct {
+	commands []PaletteCommand
+	// recent are names of recently run commands (the last one first)
+	recent []string
+}
+
+// add adds commands, replacing commands with the same names.
+func (r *commandRegistry) add(commands ...PaletteCommand) {
+	for _, c := range commands {
+		idx := slices.IndexFunc(r.commands, func(other PaletteCommand) bool { return other.Name == c.Name })
+		if idx < 0 {
+			r.commands = append(r.commands, c)
+			continue
+		}
+
+		r.commands[idx] = c
+	}
+}
+
+func (r *commandRegistry) remove(names ...string) {
+	r.commands = slices.DeleteFunc(r.commands, func(c PaletteCommand) bool {
+		return slices.Contains(names, c.Name)
+	})
+}
+
+// all returns registered commands and named key bindings of h (which may be nil) without a command.
+func (r *commandRegistry) all(h KeyBindingHandler) []PaletteCommand {
+	result := slices.Clone(r.commands)
+	if h == nil {
+		return result
+	}
+
+	for _, b := range h.KeyBindings() {
+		if b.Name == "" || b.Trigger != TriggerPress || b.Callback == nil {
+			continue
+		}
+
+		if slices.ContainsFunc(result, func(c PaletteCommand) bool { return c.Name == b.Name }) {
+			continue
+		}
+
+		result = append(result, PaletteCommand{Name: b.Name, Callback: b.Callback})
+	}
+
+	return result
+}
+
+// run runs the command name (see all) and records it as recently used. It returns false if there is no such a command.
+func (r *commandRegistry) run(name string, h KeyBindingHandler) bool {
+	commands := r.all(h)
+
+	idx := slices.IndexFunc(commands, func(c PaletteCommand) bool { return c.Name == name })
+	if idx < 0 {
+		return false
+	}
+
+	r.recent = slices.DeleteFunc(r.recent, func(n string) bool { return n == name })
+	r.recent = slices.Insert(r.recent, 0, name)
+
+	if len(r.recent) > maxRecentCommands {
+		r.recent = r.recent[:maxRecentCommands]
+	}
+
+	if c := commands[idx]; c.Callback != nil {
+		c.Callback()
+	}
+
+	return true
+}
+
+// keyBindingHandler returns Context.InputHandler if it supports key bindings.
+func keyBindingHandler() KeyBindingHandler {
+	h, _ := Context.InputHandler.(KeyBindingHandler)
+	return h
+}
+
+// RegisterCommands adds commands to CommandPalette (commands with the same names are replaced).
+// If a command has Keys, a key binding running it is registered too.
+func RegisterCommands(commands ...PaletteCommand) {
+	Context.commands.add(commands...)
+
+	h := keyBindingHandler()
+	if h == nil {
+		return
+	}
+
+	for _, c := range commands {
+		if len(c.Keys) == 0 {
+			continue
+		}
+
+		name := c.Name
+
+		h.UnregisterKeyBindings(name)
+		h.RegisterKeyBindings(KeyBinding{
+			Name:     name,
+			Keys:     c.Keys,
+			Callback: func() { RunCommand(name) },
+		})
+	}
+}
+
+// UnregisterCommands removes commands (and key bindings registered for their Keys).
+func UnregisterCommands(names ...string) {
+	h := keyBindingHandler()
+
+	for _, c := range Context.commands.commands {
+		if h != nil && len(c.Keys) > 0 && slices.Contains(names, c.Name) {
+			h.UnregisterKeyBindings(c.Name)
+		}
+	}
+
+	Context.commands.remove(names...)
+}
+
+// Commands returns registered commands and commands of named key bindings (see KeyBinding.Name).
+func Commands() []PaletteCommand {
+	return Context.commands.all(keyBindingHandler())
+}
+
+// RunCommand runs the command name. It returns false if there is no such a command.
+func RunCommand(name string) bool {
+	return Context.commands.run(name, keyBindingHandler())
+}
+
+// commandShortcut returns the shortcut of the command name (e.g. "Ctrl+S") or "" if it has no keys.
+// NOTE: it builds the keymap, so use commandKeymap to look up many commands.
+func commandShortcut(name string) string {
+	h := keyBindingHandler()
+	if h == nil {
+		return ""
+	}
+
+	return h.Keymap()[name]
+}
+
+// commandKeymap returns shortcuts of commands with keys (see commandShortcut).
+func commandKeymap() Keymap {
+	h := keyBindingHandler()
+	if h == nil {
+		return nil
+	}
+
+	return h.Keymap()
+}
@@ -2,7 +2,10 @@ package giu
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 	"sync"
+	"unsafe"
 
 	"github.com/AllenDang/cimgui-go/imgui"
 	"gopkg.in/eapache/queue.v1"
@@ -18,6 +21,11 @@ func GenAutoID(id string) ID {
 		idx, ok = idxAny.(int)
 		Assert(ok, "Context", "GenAutoID", "unexpected type of widgetIndex value: expected int, instead found %T", idxAny)
 
+		if !ok {
+			// restart counting (the invalid value is replaced below)
+			idx = -1
+		}
+
 		idx++
 	}
 
@@ -68,11 +76,44 @@ type GIUContext struct {
 	InputHandler InputHandler
 	FontAtlas    *FontAtlas
 	Translator   Translator
+	// preparedStrings are data pointers of strings returned by PrepareStringf/Tr/TrN/TrC in the current frame.
+	// They are already translated, so PrepareString returns them as-is.
+	// NOTE: pointers (not contents) are stored, so an equal string from elsewhere (e.g. a literal) is still translated.
+	preparedStrings map[*byte]struct{}
 
 	textureLoadingQueue *queue.Queue
 	textureFreeingQueue *queue.Queue
+	// invokeQueue holds functions queued by Invoke (guarded by invokeM)
+	invokeQueue *queue.Queue
+	invokeM     *sync.Mutex
 
 	cssStylesheet *CSSStylesheet
+	// cssPath is a path of widgets being built (see applyCSS)
+	cssPath []*cssElement
+	// cssWatcher is set by WatchCSSStylesheet
+	cssWatcher *cssWatcher
+
+	buildObservers []BuildObserver
+
+	// dockNodes are IDs of dock spaces and named dock nodes (see WindowWidget.DockTo)
+	dockNodes map[string]imgui.ID
+	// splitPositions are positions of sashes of NSplit widgets (see SaveSplitPositions)
+	splitPositions map[ID]*splitPositions
+
+	// profiler collects timings shown by ProfilerOverlay
+	profiler *profiler
+	// errorHandler handles errors reported by Assert (nil means panic)
+	errorHandler ErrorHandler
+	// inspector records built widgets while DebugInspector is shown
+	inspector *inspector
+	// targetFPS is set by MasterWindow.SetTargetFPS (0 if not set)
+	targetFPS uint
+	// shortcutScopes tracks focused scopes of key bindings (see ShortcutScope)
+	shortcutScopes *shortcutScopes
+	// capturingKeys is set while KeymapEditor captures a shortcut (key bindings are not triggered)
+	capturingKeys bool
+	// commands are listed by CommandPalette (see RegisterCommands)
+	commands *commandRegistry
 
 	m *sync.Mutex
 }
@@ -85,6 +126,13 @@ func CreateContext(b GIUBackend) *GIUContext {
 		FontAtlas:           newFontAtlas(),
 		textureLoadingQueue: queue.New(),
 		textureFreeingQueue: queue.New(),
+		invokeQueue:         queue.New(),
+		invokeM:             &sync.Mutex{},
+		dockNodes:           make(map[string]imgui.ID),
+		splitPositions:      make(map[ID]*splitPositions),
+		profiler:            newProfiler(),
+		shortcutScopes:      &shortcutScopes{},
+		commands:            &commandRegistry{},
 		m:                   &sync.Mutex{},
 		Translator:          &EmptyTranslator{},
 	}
@@ -121,9 +169,90 @@ func (c *GIUContext) SetDirty() {
 //
 // Not all widgets will use this. Text with user-defined input (e.g. InputText will still use FontAtlas.RegisterString).
 func (c *GIUContext) PrepareString(str string) string {
+	if _, ok := c.preparedStrings[unsafe.StringData(str)]; ok && str != "" {
+		return str
+	}
+
 	return c.Translator.Translate(str)
 }
 
+// PrepareStringf translates format and then formats it like fmt.Sprintf,
+// so that format (e.g. "%d files##counter") is the translation key (without ##id suffix which is preserved).
+// It is used by all *f constructors (e.g. Labelf). If c is nil (e.g. before NewMasterWindow), it is fmt.Sprintf.
+func (c *GIUContext) PrepareStringf(format string, args ...any) string {
+	if c == nil {
+		return fmt.Sprintf(format, args...)
+	}
+
+	key, id, hasID := strings.Cut(format, "##")
+	result := fmt.Sprintf(c.Translator.Translate(key), args...)
+
+	return c.markPrepared(result, id, hasID)
+}
+
+// Tr translates key and replaces its named placeholders (e.g. {name}) with params (see ReplacePlaceholders).
+func (c *GIUContext) Tr(key string, params map[string]any) string {
+	key, id, hasID := strings.Cut(key, "##")
+
+	return c.markPrepared(ReplacePlaceholders(c.Translator.Translate(key), params), id, hasID)
+}
+
+// TrN is like Tr, but uses plural form of key suitable for n (if Translator implements PluralTranslator).
+// {count} placeholder is replaced with n.
+func (c *GIUContext) TrN(key string, n int, params map[string]any) string {
+	key, id, hasID := strings.Cut(key, "##")
+
+	var result string
+	if pt, ok := c.Translator.(PluralTranslator); ok {
+		result = pt.TranslateN(key, n)
+	} else {
+		result = c.Translator.Translate(key)
+	}
+
+	allParams := map[string]any{"count": n}
+	maps.Copy(allParams, params)
+
+	return c.markPrepared(ReplacePlaceholders(result, allParams), id, hasID)
+}
+
+// TrC is like Tr, but translates key in context (if Translator implements ContextTranslator),
+// e.g. TrC("menu", "Open", nil) for msgctxt "menu" of a gettext catalog.
+func (c *GIUContext) TrC(context, key string, params map[string]any) string {
+	key, id, hasID := strings.Cut(key, "##")
+
+	var result string
+	if ct, ok := c.Translator.(ContextTranslator); ok {
+		result = ct.TranslateContext(context, key)
+	} else {
+		result = c.Translator.Translate(key)
+	}
+
+	return c.markPrepared(ReplacePlaceholders(result, params), id, hasID)
+}
+
+// markPrepared appends ##id suffix to translated str and marks the result so that PrepareString
+// doesn't translate it again.
+func (c *GIUContext) markPrepared(str, id string, hasID bool) string {
+	if hasID {
+		str += "##" + id
+	}
+
+	if str == "" {
+		return str
+	}
+
+	// str may share memory with other strings (e.g. a key returned by Translator as-is), so mark a copy
+	str = strings.Clone(str)
+
+	if c.preparedStrings == nil {
+		c.preparedStrings = make(map[*byte]struct{})
+	}
+
+	c.preparedStrings[unsafe.StringData(str)] = struct{}{}
+
+	return str
+}
+
 // PrepareStringSlice is a version of PrepareString that works on slices.
 func (c *GIUContext) PrepareStringSlice(strs []string) []string {
 	result := make([]string, len(strs))
@@ -142,6 +271,21 @@ func (c *GIUContext) SetCSSStylesheet(css *CSSStylesheet) {
 	c.cssStylesheet = css
 }
 
+// AddBuildObserver registers a BuildObserver.
+func (c *GIUContext) AddBuildObserver(o BuildObserver) {
+	c.buildObservers = append(c.buildObservers, o)
+}
+
+// RemoveBuildObserver unregisters a BuildObserver previously registered by AddBuildObserver.
+func (c *GIUContext) RemoveBuildObserver(o BuildObserver) {
+	for i, observer := range c.buildObservers {
+		if observer == o {
+			c.buildObservers = append(c.buildObservers[:i], c.buildObservers[i+1:]...)
+			return
+		}
+	}
+}
+
 // cleanStates removes all states that were not marked as valid during rendering,
 // then reset said flag before new usage
 // should always be called before first Get/Set state use in renderloop
@@ -177,6 +321,16 @@ func (c *GIUContext) cleanStates() {
 	c.dirty = false
 }
 
+// stateCount returns the number of states stored in the context.
+func (c *GIUContext) stateCount() (count int) {
+	c.state.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+
+	return count
+}
+
 // Backend returns the imgui.backend used by the context.
 func (c *GIUContext) Backend() GIUBackend {
 	return c.backend
@@ -208,6 +362,11 @@ func GetState[T any, PT genericDisposable[T]](c *GIUContext, id ID) PT {
 		data, isOk := s.data.(PT)
 		Assert(isOk, "Context", "GetState", "got state of unexpected type: expected %T, instead found %T", new(T), s.data)
 
+		if !isOk {
+			// callers replace the state with a new one
+			return nil
+		}
+
 		return data
 	}
 
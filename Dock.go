@@ -0,0 +1,213 @@
+package giu
+
+import "github.com/AllenDang/cimgui-go/imgui"
+
+// DockLayout describes the initial arrangement of a DockSpace:
+// a tree of nodes split horizontally or vertically, with windows assigned to leaf nodes by title.
+//
+//	giu.DockSplitHorizontal(0.25,
+//		giu.DockWindows("Files").Name("left"),
+//		giu.DockSplitVertical(0.7,
+//			giu.DockWindows("Editor"),
+//			giu.DockWindows("Console", "Problems"),
+//		),
+//	)
+type DockLayout struct {
+	name    string
+	windows []string
+
+	direction     Direction
+	ratio         float32
+	first, second *DockLayout
+}
+
+// DockWindows creates a leaf node with windows docked into it (as tabs, the first one is selected).
+// Titles are the same as passed to Window().
+func DockWindows(titles ...string) *DockLayout {
+	return &DockLayout{
+		windows: titles,
+	}
+}
+
+// DockSplitHorizontal splits a node into two nodes placed side by side.
+// ratio is a part of the width taken by left (0-1).
+func DockSplitHorizontal(ratio float32, left, right *DockLayout) *DockLayout {
+	return &DockLayout{
+		direction: DirectionLeft,
+		ratio:     ratio,
+		first:     left,
+		second:    right,
+	}
+}
+
+// DockSplitVertical splits a node into two nodes placed one above the other.
+// ratio is a part of the height taken by top (0-1).
+func DockSplitVertical(ratio float32, top, bottom *DockLayout) *DockLayout {
+	return &DockLayout{
+		direction: DirectionUp,
+		ratio:     ratio,
+		first:     top,
+		second:    bottom,
+	}
+}
+
+// Name names the node, so windows can be docked into it by WindowWidget.DockTo.
+func (l *DockLayout) Name(name string) *DockLayout {
+	l.name = name
+	return l
+}
+
+// apply builds the layout in dock node nodeID. IDs of named nodes are stored in names.
+func (l *DockLayout) apply(nodeID imgui.ID, names map[string]imgui.ID) {
+	if l == nil {
+		return
+	}
+
+	if l.name != "" {
+		names[l.name] = nodeID
+	}
+
+	if l.first != nil || l.second != nil {
+		var firstID, secondID imgui.ID
+
+		imgui.InternalDockBuilderSplitNode(nodeID, imgui.Dir(l.direction), l.ratio, &firstID, &secondID)
+		l.first.apply(firstID, names)
+		l.second.apply(secondID, names)
+
+		return
+	}
+
+	for _, title := range l.windows {
+		imgui.InternalDockBuilderDockWindow(Context.PrepareString(title), nodeID)
+	}
+}
+
+var _ Disposable = &dockSpaceState{}
+
+type dockSpaceState struct {
+	// applied is true if the layout was applied or restored from the user file in this run
+	applied bool
+	// reset is set by DockSpaceWidget.ResetLayout
+	reset bool
+}
+
+// Dispose implements Disposable interface.
+func (s *dockSpaceState) Dispose() {
+	// noop
+}
+
+var _ Widget = &DockSpaceWidget{}
+
+// DockSpaceWidget is an area other windows can be docked into (see WindowWidget.DockTo).
+// To dock windows over the whole MasterWindow, put it into SingleWindow.
+//
+// The dock arrangement is saved and restored with other user preferences (see MasterWindow.SetUserFile).
+// The layout (see Layout) is applied only if no arrangement was restored (or after ResetLayout).
+// NOTE: DockSpace enables docking (imgui.ConfigFlagsDockingEnable) when it is built for the first time,
+// so it appears in the next frame.
+type DockSpaceWidget struct {
+	id            ID
+	width, height float32
+	flags         DockNodeFlags
+	layout        *DockLayout
+}
+
+// DockSpace creates a new DockSpaceWidget.
+func DockSpace() *DockSpaceWidget {
+	return &DockSpaceWidget{
+		id: GenAutoID("DockSpace"),
+	}
+}
+
+// ID sets the id of the dock space. It is used to save the arrangement in the user file
+// and may be passed to WindowWidget.DockTo.
+func (d *DockSpaceWidget) ID(id ID) *DockSpaceWidget {
+	d.id = id
+	return d
+}
+
+// Size sets size of the dock space (0 means all available space).
+func (d *DockSpaceWidget) Size(width, height float32) *DockSpaceWidget {
+	d.width, d.height = width, height
+	return d
+}
+
+// Flags sets dock node flags.
+func (d *DockSpaceWidget) Flags(flags DockNodeFlags) *DockSpaceWidget {
+	d.flags = flags
+	return d
+}
+
+// Layout sets the initial arrangement of windows.
+func (d *DockSpaceWidget) Layout(layout *DockLayout) *DockSpaceWidget {
+	d.layout = layout
+	return d
+}
+
+// ResetLayout makes the dock space apply its Layout again (discarding the arrangement made by user)
+// next time it is built. It should be called on a widget with the same ID as the built one.
+func (d *DockSpaceWidget) ResetLayout() {
+	d.getState().reset = true
+
+	Update()
+}
+
+func (d *DockSpaceWidget) getState() *dockSpaceState {
+	var state *dockSpaceState
+	if state = GetState[dockSpaceState](Context, d.id); state == nil {
+		state = &dockSpaceState{}
+		SetState(Context, d.id, state)
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (d *DockSpaceWidget) Build() {
+	io := Context.IO()
+	if io.ConfigFlags()&imgui.ConfigFlagsDockingEnable == 0 {
+		io.SetConfigFlags(io.ConfigFlags() | imgui.ConfigFlagsDockingEnable)
+		Update()
+
+		return
+	}
+
+	state := d.getState()
+	dockID := imgui.IDStr(d.id.String())
+	size := imgui.Vec2{X: d.width, Y: d.height}
+
+	// NOTE: the arrangement from the user file is loaded before the first frame,
+	// so the node exists unless nothing was saved.
+	if !state.applied && imgui.InternalDockBuilderGetNode(dockID) != nil {
+		state.applied = true
+	}
+
+	if d.layout != nil && (!state.applied || state.reset) {
+		available := imgui.ContentRegionAvail()
+		if size.X == 0 {
+			size.X = available.X
+		}
+
+		if size.Y == 0 {
+			size.Y = available.Y
+		}
+
+		imgui.InternalDockBuilderRemoveNode(dockID)
+		imgui.InternalDockBuilderAddNodeV(dockID, imgui.DockNodeFlags(imgui.DockNodeFlagsDockSpace))
+		imgui.InternalDockBuilderSetNodeSize(dockID, size)
+
+		names := make(map[string]imgui.ID)
+		d.layout.apply(dockID, names)
+
+		for name, id := range names {
+			Context.dockNodes[name] = id
+		}
+
+		imgui.InternalDockBuilderFinish(dockID)
+	}
+
+	state.applied, state.reset = true, false
+	Context.dockNodes[d.id.String()] = dockID
+
+	imgui.DockSpaceV(dockID, size, imgui.DockNodeFlags(d.flags), nil)
+}
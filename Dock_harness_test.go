@@ -0,0 +1,60 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_DockSpace(t *testing.T) {
+	type dockedWindow struct {
+		docked bool
+		node   imgui.ID
+		pos    imgui.Vec2
+		size   imgui.Vec2
+	}
+
+	windows := make(map[string]dockedWindow)
+	record := func(title string) giu.Widget {
+		return giu.Custom(func() {
+			windows[title] = dockedWindow{
+				docked: imgui.IsWindowDocked(),
+				node:   imgui.WindowDockID(),
+				pos:    imgui.WindowPos(),
+				size:   imgui.WindowSize(),
+			}
+		})
+	}
+
+	h := giutest.New(t, 400, 300)
+	h.Run(4, func() giu.Layout {
+		return giu.Layout{
+			giu.DockSpace().ID("main").Layout(
+				giu.DockSplitHorizontal(0.3,
+					giu.DockWindows("Tools").Name("left"),
+					giu.DockWindows("Editor"),
+				),
+			),
+			giu.Custom(func() {
+				giu.Window("Tools").Layout(record("Tools"))
+				giu.Window("Editor").Layout(record("Editor"))
+				giu.Window("Console").DockTo("left").Layout(record("Console"))
+			}),
+		}
+	})
+
+	tools, editor, console := windows["Tools"], windows["Editor"], windows["Console"]
+
+	assert.True(t, tools.docked, "window of the layout not docked")
+	assert.True(t, editor.docked, "window of the layout not docked")
+	assert.NotEqual(t, tools.node, editor.node, "windows of different nodes docked together")
+	assert.Less(t, tools.pos.X, editor.pos.X, "left node is not on the left")
+	assert.Less(t, tools.size.X, editor.size.X, "left node should be narrower (ratio 0.3)")
+
+	assert.True(t, console.docked, "window not docked by DockTo")
+	assert.Equal(t, tools.node, console.node, "window not docked into the named node")
+}
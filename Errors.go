@@ -0,0 +1,294 @@
+package giu
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"golang.org/x/image/colornames"
+)
+
+// ErrWidget is an error reported by Assert or recovered by ErrorBoundary.
+type ErrWidget struct {
+	Widget  string // type of the widget (e.g. "AlignmentSetter")
+	Method  string // method which failed (e.g. "Build")
+	ID      ID     // (optional) ID of the widget
+	Message string // (optional) description of the error
+	Detail  error  // (optional) error to add extra detail (e.g. the value of recovered panic)
+}
+
+func (e ErrWidget) Error() string {
+	errStr := "giu: "
+
+	if e.Widget != "" {
+		errStr += fmt.Sprintf("(*%s).", e.Widget)
+	}
+
+	errStr += e.Method
+
+	if e.ID != "" {
+		errStr += fmt.Sprintf(" [%s]", e.ID)
+	}
+
+	if e.Message != "" {
+		errStr += ": " + e.Message
+	}
+
+	if e.Detail != nil {
+		errStr += ": " + e.Detail.Error()
+	}
+
+	return errStr
+}
+
+func (e ErrWidget) Unwrap() error {
+	return e.Detail
+}
+
+// ErrorHandler handles errors reported by Assert and ErrorBoundary (see GIUContext.SetErrorHandler).
+// If it returns, the code which reported the error continues (and may fail later, e.g. inside of ErrorBoundary).
+type ErrorHandler func(err ErrWidget)
+
+// PanicOnError is an ErrorHandler which logs the error and panics (like Assert does by default).
+func PanicOnError(err ErrWidget) {
+	log.Print(err.Error())
+	panic(err)
+}
+
+// LogErrors returns an ErrorHandler which logs errors by logger (slog.Default() if nil).
+func LogErrors(logger *slog.Logger) ErrorHandler {
+	return func(err ErrWidget) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+
+		l.Error(err.Error(),
+			slog.String("widget", err.Widget),
+			slog.String("method", err.Method),
+			slog.String("id", err.ID.String()),
+		)
+	}
+}
+
+// SetErrorHandler sets how errors reported by Assert are handled (e.g. LogErrors(nil) or a custom callback).
+// nil means PanicOnError (the default).
+// NOTE: errors recovered by ErrorBoundary are logged by slog if the handler is not set.
+func (c *GIUContext) SetErrorHandler(handler ErrorHandler) {
+	c.errorHandler = handler
+}
+
+// reportError passes err to the error handler of Context (it panics if there is none).
+func reportError(err ErrWidget) {
+	if Context == nil || Context.errorHandler == nil {
+		PanicOnError(err)
+		return
+	}
+
+	Context.errorHandler(err)
+}
+
+var _ Disposable = &errorBoundaryState{}
+
+type errorBoundaryState struct {
+	// err is the error which made the boundary show its fallback
+	err error
+}
+
+// Dispose implements Disposable interface.
+func (s *errorBoundaryState) Dispose() {
+	// noop
+}
+
+var _ Widget = &ErrorBoundaryWidget{}
+
+// ErrorBoundaryWidget recovers panics of its widgets, so one failing widget
+// (e.g. in a plugin panel) doesn't take down the whole application.
+// When a widget panics, the error is reported (see OnError), imgui state (e.g. windows and styles
+// pushed by the widgets) is restored and the fallback is shown instead of the widgets until Reset is called.
+type ErrorBoundaryWidget struct {
+	id       ID
+	fallback func(err error) Widget
+	onError  func(err ErrWidget)
+	layout   Layout
+}
+
+// ErrorBoundary creates a new ErrorBoundaryWidget. If fallback is nil, the error is shown with a "Retry" button.
+func ErrorBoundary(fallback Widget) *ErrorBoundaryWidget {
+	b := &ErrorBoundaryWidget{
+		id: GenAutoID("ErrorBoundary"),
+	}
+
+	if fallback != nil {
+		b.fallback = func(error) Widget { return fallback }
+	}
+
+	return b
+}
+
+// FallbackFunc sets a function creating the fallback for the recovered error.
+// If it returns nil, the default fallback (the error with a "Retry" button) is shown.
+func (b *ErrorBoundaryWidget) FallbackFunc(fallback func(err error) Widget) *ErrorBoundaryWidget {
+	b.fallback = fallback
+	return b
+}
+
+// ID sets the internal id of the boundary (used to store the error).
+func (b *ErrorBoundaryWidget) ID(id ID) *ErrorBoundaryWidget {
+	b.id = id
+	return b
+}
+
+// OnError sets a callback receiving recovered errors. If it is not set, errors are passed to the error handler
+// of Context (see GIUContext.SetErrorHandler) or, if there is none, logged by slog.
+func (b *ErrorBoundaryWidget) OnError(cb func(err ErrWidget)) *ErrorBoundaryWidget {
+	b.onError = cb
+	return b
+}
+
+// To sets widgets guarded by the boundary.
+func (b *ErrorBoundaryWidget) To(widgets ...Widget) *ErrorBoundaryWidget {
+	b.layout = widgets
+	return b
+}
+
+// Err returns the error which made the boundary show its fallback (nil if it shows its widgets).
+func (b *ErrorBoundaryWidget) Err() error {
+	return b.getState().err
+}
+
+// Reset makes the boundary build its widgets again.
+// It should be called on a widget with the same ID as the built one.
+func (b *ErrorBoundaryWidget) Reset() {
+	b.getState().err = nil
+
+	Update()
+}
+
+func (b *ErrorBoundaryWidget) getState() *errorBoundaryState {
+	var state *errorBoundaryState
+	if state = GetState[errorBoundaryState](Context, b.id); state == nil {
+		state = &errorBoundaryState{}
+		SetState(Context, b.id, state)
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (b *ErrorBoundaryWidget) Build() {
+	state := b.getState()
+
+	if state.err == nil {
+		err, ok := b.buildLayout()
+		if ok {
+			return
+		}
+
+		state.err = err
+		b.report(err)
+	}
+
+	if b.fallback != nil {
+		if fallback := b.fallback(state.err); fallback != nil {
+			fallback.Build()
+			return
+		}
+	}
+
+	// the stack trace of recovered panics is shown in the tooltip only
+	message, _, _ := strings.Cut(state.err.Error(), "\n")
+
+	Layout{
+		Style().SetColor(StyleColorText, colornames.Orangered).To(
+			Label(message).Wrapped(true),
+		),
+		Tooltip(state.err.Error()),
+		Button("Retry").OnClick(b.Reset),
+	}.Build()
+}
+
+// buildLayout builds widgets of the boundary. If one of them panics, it restores imgui state and returns the error.
+func (b *ErrorBoundaryWidget) buildLayout() (err ErrWidget, ok bool) {
+	recovery := imgui.NewErrorRecoveryState()
+	defer recovery.Destroy()
+
+	imgui.InternalErrorRecoveryStoreState(recovery)
+
+	cssDepth := len(Context.cssPath)
+
+	for _, w := range b.layout {
+		if err, ok = b.buildWidget(w); !ok {
+			// NOTE: imgui reports each recovered call (e.g. missing End) as an error; don't let it assert.
+			io := Context.IO()
+			enableAssert := io.ConfigErrorRecoveryEnableAssert()
+			io.SetConfigErrorRecoveryEnableAssert(false)
+			imgui.InternalErrorRecoveryTryToRecoverState(recovery)
+			io.SetConfigErrorRecoveryEnableAssert(enableAssert)
+
+			Context.cssPath = Context.cssPath[:cssDepth]
+
+			return err, false
+		}
+	}
+
+	return ErrWidget{}, true
+}
+
+func (b *ErrorBoundaryWidget) buildWidget(w Widget) (err ErrWidget, ok bool) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err, ok = errWidgetFromPanic(r, w), false
+	}()
+
+	Layout{w}.Build()
+
+	return ErrWidget{}, true
+}
+
+func (b *ErrorBoundaryWidget) report(err ErrWidget) {
+	switch {
+	case b.onError != nil:
+		b.onError(err)
+	case Context.errorHandler != nil:
+		Context.errorHandler(err)
+	default:
+		LogErrors(nil)(err)
+	}
+}
+
+// errWidgetFromPanic converts value recovered from panic of w.Build to ErrWidget.
+// Errors reported by Assert are returned as they are.
+func errWidgetFromPanic(r any, w Widget) ErrWidget {
+	if err, ok := r.(ErrWidget); ok {
+		return err
+	}
+
+	// type name without the package (e.g. "ButtonWidget"), like widget names passed to Assert
+	widget := strings.TrimPrefix(fmt.Sprintf("%T", w), "*")
+	if _, name, ok := strings.Cut(widget, "."); ok {
+		widget = name
+	}
+
+	result := ErrWidget{
+		Widget: widget,
+		Method: "Build",
+	}
+
+	result.ID, _ = WidgetID(w)
+
+	if err, ok := r.(error); ok {
+		result.Detail = fmt.Errorf("%w\n%s", err, debug.Stack())
+	} else {
+		result.Detail = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+	}
+
+	return result
+}
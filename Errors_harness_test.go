@@ -0,0 +1,42 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_ErrorBoundary(t *testing.T) {
+	var reported []giu.ErrWidget
+
+	clicked := 0
+
+	h := giutest.New(t, 400, 300)
+	h.Run(3, func() giu.Layout {
+		return giu.Layout{
+			giu.ErrorBoundary(giu.Label("Plugin failed")).
+				OnError(func(err giu.ErrWidget) { reported = append(reported, err) }).
+				To(
+					giu.Custom(func() {
+						giu.PushItemSpacing(0, 0)
+						panic("boom")
+					}),
+				),
+			giu.ErrorBoundary(nil).
+				FallbackFunc(func(error) giu.Widget { return nil }).
+				OnError(func(giu.ErrWidget) {}).
+				To(giu.Custom(func() { panic("no fallback") })),
+			giu.Button("Run").OnClick(func() { clicked++ }),
+		}
+	})
+
+	h.MustFind("Plugin failed")
+	h.MustFind("Retry")
+	h.Click("Run")
+
+	assert.Equal(t, 1, clicked, "widgets after the boundary not built")
+	assert.Len(t, reported, 1, "error should be reported once")
+}
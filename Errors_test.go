@@ -0,0 +1,78 @@
+package giu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ErrWidget(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      ErrWidget
+		expected string
+	}{
+		{"assert", ErrWidget{Widget: "AlignmentSetter", Method: "Build", Message: "unknown align type 5"}, "giu: (*AlignmentSetter).Build: unknown align type 5"},
+		{"no widget", ErrWidget{Method: "Invoke", Message: "no context"}, "giu: Invoke: no context"},
+		{"panic", ErrWidget{Widget: "ButtonWidget", Method: "Build", ID: "Save##0", Detail: errors.New("boom")}, "giu: (*ButtonWidget).Build [Save##0]: boom"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.err.Error(), "unexpected message")
+		})
+	}
+}
+
+func Test_ErrWidgetFromPanic(t *testing.T) {
+	detail := errors.New("boom")
+	err := errWidgetFromPanic(detail, &ButtonWidget{id: "Save##0"})
+
+	assert.Equal(t, "ButtonWidget", err.Widget, "unexpected widget")
+	assert.Equal(t, "Build", err.Method, "unexpected method")
+	assert.Equal(t, ID("Save##0"), err.ID, "unexpected ID")
+	assert.ErrorIs(t, err, detail, "panic value should be wrapped")
+
+	err = errWidgetFromPanic("boom", &RowWidget{})
+	assert.True(t, strings.HasPrefix(err.Error(), "giu: (*RowWidget).Build: panic: boom\n"), "unexpected message: %s", err)
+	assert.Contains(t, err.Error(), "runtime/debug.Stack", "stack trace not added")
+
+	reported := ErrWidget{Widget: "CSSTagWidget", Method: "Build", Message: "unknown tag"}
+	assert.Equal(t, reported, errWidgetFromPanic(reported, &RowWidget{}), "errors reported by Assert should be kept")
+}
+
+func Test_AssertPanicsWithErrWidget(t *testing.T) {
+	assert.PanicsWithValue(t, ErrWidget{Widget: "somewidget", Method: "somemethod", Message: "value 1"}, func() {
+		Assert(false, "somewidget", "somemethod", "value %d", 1)
+	})
+}
+
+func Test_AssertWithErrorHandler(t *testing.T) {
+	prev := Context
+	Context = CreateContext(nil)
+
+	defer func() {
+		Context = prev
+	}()
+
+	var reported []ErrWidget
+
+	Context.SetErrorHandler(func(err ErrWidget) {
+		reported = append(reported, err)
+	})
+
+	AssertID(false, "AlignmentSetter", "Build", "Align##0", "unknown align type %d", 5)
+	Assert(true, "somewidget", "somemethod", "not reported")
+
+	assert.Equal(t, []ErrWidget{
+		{Widget: "AlignmentSetter", Method: "Build", ID: "Align##0", Message: "unknown align type 5"},
+	}, reported, "unexpected errors reported")
+
+	// callers fall back to safe values when the handler doesn't panic
+	SetState(Context, "state", &teststate2{})
+	assert.Nil(t, GetState[teststate](Context, "state"), "state of unexpected type should not be returned")
+	assert.Equal(t, float32(12), Style().SetFontSize(12).SetFontSize(-1).fontSize, "invalid font size should be ignored")
+	assert.Len(t, reported, 3, "failed assertions should be reported")
+}
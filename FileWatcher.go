@@ -0,0 +1,51 @@
+package giu
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileWatcher polls a file for changes (used by WatchCSSStylesheet and UIDefinition.Watch).
+type fileWatcher struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// changed checks whether the file has changed since the last call.
+func (f *fileWatcher) changed() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, fmt.Errorf("checking %s: %w", f.path, err)
+	}
+
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+
+	f.modTime, f.size = info.ModTime(), info.Size()
+
+	return true, nil
+}
+
+// poll checks the file every interval until stop is closed and calls onChange when it has changed.
+func (f *fileWatcher) poll(interval time.Duration, stop <-chan struct{}, onChange func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		// NOTE: file may be missing for a while when an editor replaces it; just wait for it.
+		if changed, err := f.changed(); err != nil || !changed {
+			continue
+		}
+
+		onChange()
+	}
+}
@@ -576,3 +576,25 @@ const (
 	PopupFlagsAnyPopupLevel PopupFlags = PopupFlags(imgui.PopupFlagsAnyPopupLevel)
 	PopupFlagsAnyPopup      PopupFlags = PopupFlags(imgui.PopupFlagsAnyPopup)
 )
+
+// DockNodeFlags represents flags for DockSpace.
+type DockNodeFlags imgui.DockNodeFlags
+
+// dock node flags.
+const (
+	DockNodeFlagsNone DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsNone)
+	// Don't display the dockspace node but keep it alive. Windows docked into this dockspace node won't be undocked.
+	DockNodeFlagsKeepAliveOnly DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsKeepAliveOnly)
+	// Disable docking over the Central Node, which will be always kept empty.
+	DockNodeFlagsNoDockingOverCentralNode DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsNoDockingOverCentralNode)
+	// Enable passthru dockspace: the Central Node is transparent and lets inputs pass through.
+	DockNodeFlagsPassthruCentralNode DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsPassthruCentralNode)
+	// Disable other windows/nodes from splitting this node.
+	DockNodeFlagsNoDockingSplit DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsNoDockingSplit)
+	// Disable resizing node using the splitter/separators.
+	DockNodeFlagsNoResize DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsNoResize)
+	// Tab bar will automatically hide when there is a single window in the dock node.
+	DockNodeFlagsAutoHideTabBar DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsAutoHideTabBar)
+	// Disable undocking this node.
+	DockNodeFlagsNoUndocking DockNodeFlags = DockNodeFlags(imgui.DockNodeFlagsNoUndocking)
+)
@@ -0,0 +1,379 @@
+package giu
+
+import (
+	"image"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// FlexDirection is the main axis of FlexWidget.
+type FlexDirection byte
+
+const (
+	// FlexRow places items from left to right.
+	FlexRow FlexDirection = iota
+	// FlexColumn places items from top to bottom.
+	FlexColumn
+)
+
+// FlexJustify specifies how free space on the main axis of FlexWidget is distributed.
+type FlexJustify byte
+
+const (
+	// JustifyStart packs items at the start.
+	JustifyStart FlexJustify = iota
+	// JustifyCenter packs items in the center.
+	JustifyCenter
+	// JustifyEnd packs items at the end.
+	JustifyEnd
+	// JustifySpaceBetween puts equal space between items (the first and the last item touch the edges).
+	JustifySpaceBetween
+)
+
+// FlexAlign specifies how items are placed on the cross axis of FlexWidget.
+type FlexAlign byte
+
+const (
+	// FlexAlignStart places items at the start (top for FlexRow).
+	FlexAlignStart FlexAlign = iota
+	// FlexAlignCenter centers items (e.g. vertically for FlexRow).
+	FlexAlignCenter
+	// FlexAlignEnd places items at the end.
+	FlexAlignEnd
+	// FlexAlignStretch makes items as wide as the container (FlexColumn only, see FlexItemWidget.Grow for the limitations).
+	// In FlexRow it is the same as FlexAlignStart.
+	FlexAlignStretch
+)
+
+var _ Widget = &FlexItemWidget{}
+
+// FlexItemWidget wraps a widget to set its flex properties (see Flex).
+// Widgets passed to Flex directly behave like FlexItem(widget).
+type FlexItemWidget struct {
+	widget    Widget
+	grow      float32
+	shrink    float32
+	basis     float32
+	hasBasis  bool
+	align     FlexAlign
+	alignSelf bool
+}
+
+// FlexItem creates a new FlexItemWidget.
+func FlexItem(widget Widget) *FlexItemWidget {
+	return &FlexItemWidget{
+		widget: widget,
+		shrink: 1,
+	}
+}
+
+// Grow sets how much of the free space the item takes (relatively to other items).
+// Like in CSS "flex: 1", an item which grows and has no Basis starts from size 0.
+// NOTE: the size is applied by PushItemWidth, so only widgets using item width
+// (e.g. InputText, SliderInt, Combo) get wider in FlexRow. Other widgets are placed at the start of their space.
+func (f *FlexItemWidget) Grow(grow float32) *FlexItemWidget {
+	f.grow = grow
+	return f
+}
+
+// Shrink sets how much the item shrinks when items don't fit (default 1, 0 disables shrinking).
+// Items shrink in FlexRow only (with the same limitations as Grow).
+func (f *FlexItemWidget) Shrink(shrink float32) *FlexItemWidget {
+	f.shrink = shrink
+	return f
+}
+
+// Basis sets the initial size of the item on the main axis (default: measured size of the widget).
+func (f *FlexItemWidget) Basis(basis float32) *FlexItemWidget {
+	f.basis = basis
+	f.hasBasis = true
+
+	return f
+}
+
+// AlignSelf overrides FlexWidget.AlignItems for this item.
+func (f *FlexItemWidget) AlignSelf(align FlexAlign) *FlexItemWidget {
+	f.align = align
+	f.alignSelf = true
+
+	return f
+}
+
+// Build implements Widget interface (it builds the widget only).
+func (f *FlexItemWidget) Build() {
+	if f.widget != nil {
+		Layout{f.widget}.Build()
+	}
+}
+
+var _ Widget = &FlexWidget{}
+
+// FlexWidget is a flexbox-like container: it places items along the main axis with gap between them,
+// distributes free space among items (see FlexItemWidget.Grow) or around them (see Justify)
+// and aligns them on the cross axis (see AlignItems).
+//
+// NOTE: items are measured by building them invisibly (see GetWidgetSize) before they are placed,
+// so every item is built twice per frame.
+type FlexWidget struct {
+	direction FlexDirection
+	justify   FlexJustify
+	align     FlexAlign
+	gap       float32
+	hasGap    bool
+	width     float32
+	height    float32
+	items     []Widget
+}
+
+// Flex creates a new FlexWidget (a FlexRow by default).
+func Flex(widgets ...Widget) *FlexWidget {
+	return &FlexWidget{
+		items: widgets,
+	}
+}
+
+// Direction sets the main axis.
+func (f *FlexWidget) Direction(direction FlexDirection) *FlexWidget {
+	f.direction = direction
+	return f
+}
+
+// Justify sets how free space on the main axis is distributed.
+func (f *FlexWidget) Justify(justify FlexJustify) *FlexWidget {
+	f.justify = justify
+	return f
+}
+
+// AlignItems sets how items are placed on the cross axis.
+func (f *FlexWidget) AlignItems(align FlexAlign) *FlexWidget {
+	f.align = align
+	return f
+}
+
+// Gap sets space between items (default: item spacing of the current style).
+func (f *FlexWidget) Gap(gap float32) *FlexWidget {
+	f.gap = gap
+	f.hasGap = true
+
+	return f
+}
+
+// Size sets size of the container. 0 means: available width for width
+// and height of the highest (FlexRow) or sum of heights (FlexColumn) of items for height.
+func (f *FlexWidget) Size(width, height float32) *FlexWidget {
+	f.width, f.height = width, height
+	return f
+}
+
+// Items sets items of the container.
+func (f *FlexWidget) Items(widgets ...Widget) *FlexWidget {
+	f.items = widgets
+	return f
+}
+
+// Build implements Widget interface.
+func (f *FlexWidget) Build() {
+	if len(f.items) == 0 {
+		return
+	}
+
+	// axis returns main and cross component of v.
+	axis := func(v imgui.Vec2) (mainValue, crossValue float32) {
+		if f.direction == FlexColumn {
+			return v.Y, v.X
+		}
+
+		return v.X, v.Y
+	}
+
+	gap := f.gap
+	if !f.hasGap {
+		spacingX, spacingY := GetItemSpacing()
+		gap, _ = axis(imgui.Vec2{X: spacingX, Y: spacingY})
+	}
+
+	availableW, _ := GetAvailableRegion()
+	width := f.width
+
+	if width == 0 {
+		width = availableW
+	}
+
+	metrics := make([]flexMetrics, len(f.items))
+	aligns := make([]FlexAlign, len(f.items))
+	natural := make([]imgui.Vec2, len(f.items))
+
+	var contentMain, contentCross float32
+
+	for i, w := range f.items {
+		item, ok := w.(*FlexItemWidget)
+		if !ok {
+			item = FlexItem(w)
+		}
+
+		naturalW, naturalH := GetWidgetSize(item)
+		natural[i] = imgui.Vec2{X: naturalW, Y: naturalH}
+
+		naturalMain, naturalCross := axis(natural[i])
+		metrics[i] = flexMetrics{basis: naturalMain, grow: item.grow, shrink: item.shrink}
+
+		switch {
+		case item.hasBasis:
+			metrics[i].basis = item.basis
+		case item.grow > 0:
+			metrics[i].basis = 0
+		}
+
+		// heights can't be forced, so items of FlexColumn would overlap
+		if f.direction == FlexColumn {
+			metrics[i].shrink = 0
+		}
+
+		aligns[i] = f.align
+		if item.alignSelf {
+			aligns[i] = item.align
+		}
+
+		contentMain += metrics[i].basis
+		contentCross = max(contentCross, naturalCross)
+	}
+
+	contentMain += gap * float32(len(f.items)-1)
+
+	mainSize, crossSize := width, f.height
+	if f.direction == FlexColumn {
+		mainSize, crossSize = f.height, width
+		if mainSize == 0 {
+			mainSize = contentMain
+		}
+	} else if crossSize == 0 {
+		crossSize = contentCross
+	}
+
+	offsets, sizes := flexLayout(mainSize, gap, f.justify, metrics)
+	origin := GetCursorPos()
+
+	for i, w := range f.items {
+		naturalMain, naturalCross := axis(natural[i])
+
+		// the width pushed to the item (0 if the item is built with its natural width)
+		var pushed float32
+
+		switch {
+		case f.direction == FlexRow && sizes[i] != naturalMain:
+			pushed = sizes[i]
+		case f.direction == FlexColumn && aligns[i] == FlexAlignStretch:
+			pushed = crossSize
+		}
+
+		crossOffset := flexCrossOffset(aligns[i], crossSize, naturalCross)
+
+		pos := image.Pt(origin.X+int(offsets[i]), origin.Y+int(crossOffset))
+		if f.direction == FlexColumn {
+			pos = image.Pt(origin.X+int(crossOffset), origin.Y+int(offsets[i]))
+		}
+
+		SetCursorPos(pos)
+
+		if pushed > 0 {
+			// the difference between the natural width and the default item width is e.g. width of a label
+			// (item width is always horizontal)
+			overhead := max(natural[i].X-imgui.CalcItemWidth(), 0)
+			PushItemWidth(max(pushed-overhead, 1))
+		}
+
+		imgui.BeginGroup()
+		Layout{w}.Build()
+		imgui.EndGroup()
+
+		if pushed > 0 {
+			PopItemWidth()
+		}
+	}
+
+	// reserve the space of the container in the parent layout
+	SetCursorPos(origin)
+
+	if f.direction == FlexColumn {
+		Dummy(crossSize, mainSize).Build()
+	} else {
+		Dummy(mainSize, crossSize).Build()
+	}
+}
+
+// flexMetrics are flex properties of an item on the main axis.
+type flexMetrics struct {
+	basis, grow, shrink float32
+}
+
+// flexLayout computes offsets and sizes of items on the main axis of size available.
+func flexLayout(available, gap float32, justify FlexJustify, items []flexMetrics) (offsets, sizes []float32) {
+	offsets = make([]float32, len(items))
+	sizes = make([]float32, len(items))
+
+	if len(items) == 0 {
+		return offsets, sizes
+	}
+
+	used := gap * float32(len(items)-1)
+
+	var totalGrow, totalShrink float32
+
+	for i, item := range items {
+		sizes[i] = item.basis
+		used += item.basis
+		totalGrow += item.grow
+		totalShrink += item.shrink * item.basis
+	}
+
+	free := available - used
+
+	switch {
+	case free > 0 && totalGrow > 0:
+		for i, item := range items {
+			sizes[i] += free * item.grow / totalGrow
+		}
+
+		free = 0
+	case free < 0 && totalShrink > 0:
+		for i, item := range items {
+			sizes[i] = max(sizes[i]+free*item.shrink*item.basis/totalShrink, 0)
+		}
+
+		free = 0
+	}
+
+	free = max(free, 0)
+
+	var position float32
+
+	switch justify {
+	case JustifyCenter:
+		position = free / 2
+	case JustifyEnd:
+		position = free
+	case JustifySpaceBetween:
+		if len(items) > 1 {
+			gap += free / float32(len(items)-1)
+		}
+	}
+
+	for i := range items {
+		offsets[i] = position
+		position += sizes[i] + gap
+	}
+
+	return offsets, sizes
+}
+
+// flexCrossOffset returns offset of an item of size size on the cross axis of size available.
+func flexCrossOffset(align FlexAlign, available, size float32) float32 {
+	switch align {
+	case FlexAlignCenter:
+		return max((available-size)/2, 0)
+	case FlexAlignEnd:
+		return max(available-size, 0)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,49 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_Flex(t *testing.T) {
+	const width, gap = 300, 10
+
+	var text string
+
+	h := giutest.New(t, 400, 300)
+	// items are measured in the same frame, so the first frame is laid out already
+	h.Run(1, func() giu.Layout {
+		return giu.Layout{
+			giu.Flex(
+				giu.Button("A").Size(50, 20),
+				giu.Button("B").Size(60, 30),
+			).Size(width, 0).Gap(gap).Justify(giu.JustifyEnd).AlignItems(giu.FlexAlignCenter),
+			giu.Flex(
+				giu.Button("C").Size(50, 20),
+				giu.FlexItem(giu.InputText(&text).ID("##grow")).Grow(1),
+			).Size(width, 0).Gap(gap),
+		}
+	})
+
+	a, b, c := h.MustFind("A").Rect, h.MustFind("B").Rect, h.MustFind("C").Rect
+	origin := c.Min.X
+
+	// JustifyEnd
+	assert.Equal(t, origin+width-60-gap-50, a.Min.X, "items not justified to the end")
+	assert.Equal(t, a.Max.X+gap, b.Min.X, "unexpected gap")
+	assert.Equal(t, origin+width, b.Max.X, "the last item doesn't touch the end")
+
+	// FlexAlignCenter
+	assert.Equal(t, b.Min.Y+(30-20)/2, a.Min.Y, "items not centered vertically")
+
+	// Grow
+	grow, ok := h.FindByID("##grow")
+	if assert.True(t, ok, "growing item not built") {
+		assert.Equal(t, c.Max.X+gap, grow.Rect.Min.X, "unexpected position of the growing item")
+		assert.Equal(t, origin+width, grow.Rect.Max.X, "item didn't grow to the end")
+	}
+}
@@ -0,0 +1,88 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlexLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		available float32
+		justify   FlexJustify
+		items     []flexMetrics
+		offsets   []float32
+		sizes     []float32
+	}{
+		{
+			name:      "start",
+			available: 100,
+			items:     []flexMetrics{{basis: 20}, {basis: 30}},
+			offsets:   []float32{0, 30},
+			sizes:     []float32{20, 30},
+		},
+		{
+			name:      "center",
+			available: 100,
+			justify:   JustifyCenter,
+			items:     []flexMetrics{{basis: 20}, {basis: 30}},
+			offsets:   []float32{20, 50},
+			sizes:     []float32{20, 30},
+		},
+		{
+			name:      "end",
+			available: 100,
+			justify:   JustifyEnd,
+			items:     []flexMetrics{{basis: 20}, {basis: 30}},
+			offsets:   []float32{40, 70},
+			sizes:     []float32{20, 30},
+		},
+		{
+			name:      "space between",
+			available: 100,
+			justify:   JustifySpaceBetween,
+			items:     []flexMetrics{{basis: 20}, {basis: 20}, {basis: 20}},
+			offsets:   []float32{0, 40, 80},
+			sizes:     []float32{20, 20, 20},
+		},
+		{
+			name:      "grow",
+			available: 100,
+			justify:   JustifyEnd,
+			items:     []flexMetrics{{basis: 20}, {grow: 1}, {grow: 3}},
+			offsets:   []float32{0, 30, 55},
+			sizes:     []float32{20, 15, 45},
+		},
+		{
+			name:      "shrink",
+			available: 70,
+			items:     []flexMetrics{{basis: 40, shrink: 1}, {basis: 40, shrink: 0}},
+			offsets:   []float32{0, 30},
+			sizes:     []float32{20, 40},
+		},
+		{
+			name:      "overflow",
+			available: 50,
+			justify:   JustifyCenter,
+			items:     []flexMetrics{{basis: 40}, {basis: 40}},
+			offsets:   []float32{0, 50},
+			sizes:     []float32{40, 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offsets, sizes := flexLayout(tt.available, 10, tt.justify, tt.items)
+			assert.Equal(t, tt.offsets, offsets, "unexpected offsets")
+			assert.Equal(t, tt.sizes, sizes, "unexpected sizes")
+		})
+	}
+}
+
+func TestFlexCrossOffset(t *testing.T) {
+	assert.Equal(t, float32(0), flexCrossOffset(FlexAlignStart, 30, 10), "unexpected start offset")
+	assert.Equal(t, float32(10), flexCrossOffset(FlexAlignCenter, 30, 10), "unexpected center offset")
+	assert.Equal(t, float32(20), flexCrossOffset(FlexAlignEnd, 30, 10), "unexpected end offset")
+	assert.Equal(t, float32(0), flexCrossOffset(FlexAlignEnd, 10, 30), "item larger than line should start at 0")
+}
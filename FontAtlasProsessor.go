@@ -147,6 +147,17 @@ func (a *FontAtlas) AddFont(fontName string) *FontInfo {
 	return &fi
 }
 
+// FindFont returns a font previously added by AddFont or AddFontFromBytes (or nil if there is no such a font).
+func (a *FontAtlas) FindFont(fontName string) *FontInfo {
+	for i := range a.extraFonts {
+		if a.extraFonts[i].fontName == fontName {
+			return &a.extraFonts[i]
+		}
+	}
+
+	return nil
+}
+
 // AddFontFromBytes does similar to AddFont, but using data from memory.
 func (a *FontAtlas) AddFontFromBytes(fontName string, fontBytes []byte) *FontInfo {
 	fi := FontInfo{
@@ -0,0 +1,439 @@
+package giu
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// gridTrackKind is a kind of GridTrack.
+type gridTrackKind byte
+
+const (
+	gridTrackAuto gridTrackKind = iota
+	gridTrackPx
+	gridTrackFr
+)
+
+// GridTrack is a size of a column or a row of GridLayoutWidget.
+type GridTrack struct {
+	kind  gridTrackKind
+	value float32
+}
+
+// GridPx returns a track of fixed size (in pixels).
+func GridPx(size float32) GridTrack {
+	return GridTrack{kind: gridTrackPx, value: size}
+}
+
+// GridFr returns a track taking a fraction of the free space (relatively to other fr tracks).
+// If the grid has no height set, fr rows behave like GridAuto.
+func GridFr(fraction float32) GridTrack {
+	return GridTrack{kind: gridTrackFr, value: fraction}
+}
+
+// GridAuto returns a track as large as the largest widget placed in it.
+// NOTE: widgets spanning more tracks are not taken into account.
+// Cells placed in auto tracks are built twice per frame (see GridLayoutWidget).
+func GridAuto() GridTrack {
+	return GridTrack{kind: gridTrackAuto}
+}
+
+// String returns the track in the format accepted by ParseGridTracks.
+func (t GridTrack) String() string {
+	value := strconv.FormatFloat(float64(t.value), 'f', -1, 32)
+
+	switch t.kind {
+	case gridTrackPx:
+		return value + "px"
+	case gridTrackFr:
+		return value + "fr"
+	default:
+		return "auto"
+	}
+}
+
+// ParseGridTracks parses space-separated tracks (like CSS grid-template-columns), e.g. "100px 1fr auto".
+// A number without unit is in pixels.
+func ParseGridTracks(s string) ([]GridTrack, error) {
+	fields := strings.Fields(s)
+	result := make([]GridTrack, len(fields))
+
+	for i, field := range fields {
+		if field == "auto" {
+			result[i] = GridAuto()
+			continue
+		}
+
+		number, unit := field, "px"
+		if n, ok := strings.CutSuffix(field, "fr"); ok {
+			number, unit = n, "fr"
+		} else if n, ok := strings.CutSuffix(field, "px"); ok {
+			number = n
+		}
+
+		value, err := strconv.ParseFloat(number, 32)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid grid track %q", field)
+		}
+
+		result[i] = GridPx(float32(value))
+		if unit == "fr" {
+			result[i] = GridFr(float32(value))
+		}
+	}
+
+	return result, nil
+}
+
+var _ Widget = &GridCellWidget{}
+
+// GridCellWidget is a cell of GridLayoutWidget: a layout placed at a row and a column, which may span more tracks.
+type GridCellWidget struct {
+	layout   Layout
+	row, col int
+	placed   bool
+	rowSpan  int
+	colSpan  int
+	paddingX float32
+	paddingY float32
+	alignX   FlexAlign
+	alignY   FlexAlign
+}
+
+// GridCell creates a new GridCellWidget. Without At, it is placed in the next free cell.
+func GridCell(widgets ...Widget) *GridCellWidget {
+	return &GridCellWidget{
+		layout:  widgets,
+		rowSpan: 1,
+		colSpan: 1,
+	}
+}
+
+// At places the cell at row and col (0-based). Negative values are treated as 0
+// and columns past the last one as the last column.
+func (c *GridCellWidget) At(row, col int) *GridCellWidget {
+	c.row, c.col = row, col
+	c.placed = true
+
+	return c
+}
+
+// Span sets number of rows and columns the cell takes.
+func (c *GridCellWidget) Span(rows, cols int) *GridCellWidget {
+	c.rowSpan, c.colSpan = max(rows, 1), max(cols, 1)
+	return c
+}
+
+// Padding sets space between the cell's edges and its layout.
+func (c *GridCellWidget) Padding(x, y float32) *GridCellWidget {
+	c.paddingX, c.paddingY = x, y
+	return c
+}
+
+// Align sets alignment of the layout in the cell (horizontal and vertical).
+// FlexAlignStretch makes widgets using item width (e.g. InputText) as wide as the cell; vertically it is FlexAlignStart.
+// Aligned cells (other than FlexAlignStart) are built twice per frame (see GridLayoutWidget).
+func (c *GridCellWidget) Align(x, y FlexAlign) *GridCellWidget {
+	c.alignX, c.alignY = x, y
+	return c
+}
+
+// Build implements Widget interface (it builds the layout only).
+func (c *GridCellWidget) Build() {
+	c.layout.Build()
+}
+
+// needsSize returns true if the cell's layout must be measured to place it.
+func (c *GridCellWidget) needsSize() bool {
+	return c.alignX != FlexAlignStart || c.alignY == FlexAlignCenter || c.alignY == FlexAlignEnd
+}
+
+var _ Widget = &GridLayoutWidget{}
+
+// GridLayoutWidget places cells in a grid of column and row tracks (fixed, fractional or auto, see GridTrack).
+// Unlike Table, it is a plain layout: it doesn't interfere with keyboard navigation nor styling.
+//
+// NOTE: cells in auto tracks and aligned cells (see GridCellWidget.Align) are measured by building them invisibly
+// (see GetWidgetSize) before they are placed, so their widgets are built twice per frame.
+// Side effects of building (e.g. callbacks, state changes) may happen twice too.
+type GridLayoutWidget struct {
+	columns   []GridTrack
+	rows      []GridTrack
+	columnGap float32
+	rowGap    float32
+	hasGap    bool
+	width     float32
+	height    float32
+	cells     []*GridCellWidget
+}
+
+// GridLayout creates a new GridLayoutWidget. Widgets which aren't GridCellWidgets are put into cells placed
+// in the next free cell (row by row).
+// NOTE: Grid is the constructor of GridGizmo.
+func GridLayout(widgets ...Widget) *GridLayoutWidget {
+	return (&GridLayoutWidget{}).Cells(widgets...)
+}
+
+// Columns sets column tracks (default: one auto column).
+func (g *GridLayoutWidget) Columns(tracks ...GridTrack) *GridLayoutWidget {
+	g.columns = tracks
+	return g
+}
+
+// Rows sets row tracks. Rows needed by cells, but not set, are auto.
+func (g *GridLayoutWidget) Rows(tracks ...GridTrack) *GridLayoutWidget {
+	g.rows = tracks
+	return g
+}
+
+// Gap sets space between columns and rows (default: item spacing of the current style).
+func (g *GridLayoutWidget) Gap(column, row float32) *GridLayoutWidget {
+	g.columnGap, g.rowGap = column, row
+	g.hasGap = true
+
+	return g
+}
+
+// Size sets size of the grid. 0 means available width for width and height of rows for height.
+func (g *GridLayoutWidget) Size(width, height float32) *GridLayoutWidget {
+	g.width, g.height = width, height
+	return g
+}
+
+// Cells adds cells to the grid (see GridLayout).
+func (g *GridLayoutWidget) Cells(widgets ...Widget) *GridLayoutWidget {
+	for _, w := range widgets {
+		cell, ok := w.(*GridCellWidget)
+		if !ok {
+			cell = GridCell(w)
+		}
+
+		g.cells = append(g.cells, cell)
+	}
+
+	return g
+}
+
+// Build implements Widget interface.
+func (g *GridLayoutWidget) Build() {
+	if len(g.cells) == 0 {
+		return
+	}
+
+	columns := g.columns
+	if len(columns) == 0 {
+		columns = []GridTrack{GridAuto()}
+	}
+
+	placements := gridPlace(g.cells, len(columns))
+
+	rows := g.rows
+	for _, p := range placements {
+		for len(rows) < p.Max.Y {
+			rows = append(rows, GridAuto())
+		}
+	}
+
+	columnGap, rowGap := g.columnGap, g.rowGap
+	if !g.hasGap {
+		columnGap, rowGap = GetItemSpacing()
+	}
+
+	// measure cells which need it
+	sizes := make([]imgui.Vec2, len(g.cells))
+	autoColumns := make([]float32, len(columns))
+	autoRows := make([]float32, len(rows))
+
+	for i, cell := range g.cells {
+		p := placements[i]
+		inAutoColumn := p.Dx() == 1 && columns[p.Min.X].kind == gridTrackAuto
+		inAutoRow := p.Dy() == 1 && (rows[p.Min.Y].kind == gridTrackAuto || (rows[p.Min.Y].kind == gridTrackFr && g.height == 0))
+
+		if !inAutoColumn && !inAutoRow && !cell.needsSize() {
+			continue
+		}
+
+		w, h := GetWidgetSize(cell.layout)
+		sizes[i] = imgui.Vec2{X: w, Y: h}
+
+		if inAutoColumn {
+			autoColumns[p.Min.X] = max(autoColumns[p.Min.X], w+2*cell.paddingX)
+		}
+
+		if inAutoRow {
+			autoRows[p.Min.Y] = max(autoRows[p.Min.Y], h+2*cell.paddingY)
+		}
+	}
+
+	width := g.width
+	if width == 0 {
+		width, _ = GetAvailableRegion()
+	}
+
+	height := g.height
+	if height == 0 {
+		height = -1
+	}
+
+	columnOffsets, columnSizes := gridTracks(columns, width, columnGap, autoColumns)
+	rowOffsets, rowSizes := gridTracks(rows, height, rowGap, autoRows)
+
+	origin := GetCursorPos()
+
+	for i, cell := range g.cells {
+		p := placements[i]
+		x, cellW := gridSpan(columnOffsets, columnSizes, columnGap, p.Min.X, p.Max.X)
+		y, cellH := gridSpan(rowOffsets, rowSizes, rowGap, p.Min.Y, p.Max.Y)
+
+		x += cell.paddingX + flexCrossOffset(cell.alignX, cellW-2*cell.paddingX, sizes[i].X)
+		y += cell.paddingY + flexCrossOffset(cell.alignY, cellH-2*cell.paddingY, sizes[i].Y)
+
+		SetCursorPos(image.Pt(origin.X+int(x), origin.Y+int(y)))
+
+		if cell.alignX == FlexAlignStretch {
+			// the difference between size of the layout and the default item width is e.g. width of a label
+			overhead := max(sizes[i].X-imgui.CalcItemWidth(), 0)
+			PushItemWidth(max(cellW-2*cell.paddingX-overhead, 1))
+		}
+
+		imgui.BeginGroup()
+		cell.Build()
+		imgui.EndGroup()
+
+		if cell.alignX == FlexAlignStretch {
+			PopItemWidth()
+		}
+	}
+
+	// reserve the space of the grid in the parent layout
+	last := len(rowSizes) - 1
+	SetCursorPos(origin)
+	Dummy(width, rowOffsets[last]+rowSizes[last]).Build()
+}
+
+// gridPlace returns rectangles (in tracks) of cells in a grid with columns columns.
+// Cells without position are placed in the first free cell after the previous cell (row by row).
+func gridPlace(cells []*GridCellWidget, columns int) []image.Rectangle {
+	result := make([]image.Rectangle, len(cells))
+	taken := make(map[image.Point]bool)
+
+	free := func(r image.Rectangle) bool {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if taken[image.Pt(x, y)] {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	take := func(r image.Rectangle) {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				taken[image.Pt(x, y)] = true
+			}
+		}
+	}
+
+	// explicitly placed cells take their place first
+	for i, cell := range cells {
+		if cell.placed {
+			row, col := max(cell.row, 0), min(max(cell.col, 0), columns-1)
+			result[i] = image.Rect(col, row, col+min(cell.colSpan, columns-col), row+cell.rowSpan)
+			take(result[i])
+		}
+	}
+
+	var cursor image.Point
+
+	for i, cell := range cells {
+		if cell.placed {
+			continue
+		}
+
+		span := min(cell.colSpan, columns)
+
+		for {
+			if cursor.X+span > columns {
+				cursor = image.Pt(0, cursor.Y+1)
+			}
+
+			r := image.Rect(cursor.X, cursor.Y, cursor.X+span, cursor.Y+cell.rowSpan)
+			if free(r) {
+				result[i] = r
+				take(r)
+				cursor.X += span
+
+				break
+			}
+
+			cursor.X++
+		}
+	}
+
+	return result
+}
+
+// gridTracks computes offsets and sizes of tracks.
+// available < 0 means the size isn't known (fr tracks are auto then).
+// autoSizes are sizes of the largest widgets in tracks.
+func gridTracks(tracks []GridTrack, available, gap float32, autoSizes []float32) (offsets, sizes []float32) {
+	offsets = make([]float32, len(tracks))
+	sizes = make([]float32, len(tracks))
+
+	if len(tracks) == 0 {
+		return offsets, sizes
+	}
+
+	free := available - gap*float32(len(tracks)-1)
+
+	var totalFr float32
+
+	for i, track := range tracks {
+		switch {
+		case track.kind == gridTrackPx:
+			sizes[i] = track.value
+		case track.kind == gridTrackFr && available >= 0:
+			totalFr += track.value
+			continue
+		default:
+			sizes[i] = autoSizes[i]
+		}
+
+		free -= sizes[i]
+	}
+
+	if totalFr > 0 {
+		free = max(free, 0)
+
+		for i, track := range tracks {
+			if track.kind == gridTrackFr {
+				sizes[i] = free * track.value / totalFr
+			}
+		}
+	}
+
+	var position float32
+
+	for i := range tracks {
+		offsets[i] = position
+		position += sizes[i] + gap
+	}
+
+	return offsets, sizes
+}
+
+// gridSpan returns offset and size of tracks from (inclusive) to to (exclusive) with gaps between them.
+func gridSpan(offsets, sizes []float32, gap float32, from, to int) (offset, size float32) {
+	for i := from; i < to; i++ {
+		size += sizes[i]
+	}
+
+	return offsets[from], size + gap*float32(to-from-1)
+}
@@ -0,0 +1,37 @@
+package giu_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_GridLayout(t *testing.T) {
+	h := giutest.New(t, 400, 300)
+	h.Run(3, func() giu.Layout {
+		// columns: 0-100, 110-190 and 200-360; rows: 0-20 and 25-45
+		return giu.Layout{
+			giu.GridLayout(
+				giu.GridCell(giu.Button("a").Size(20, 20)).At(0, 0),
+				giu.GridCell(giu.Button("b").Size(20, 20)).At(0, 1).Align(giu.FlexAlignEnd, giu.FlexAlignStart),
+				giu.GridCell(giu.Button("c").Size(20, 20)).At(1, 0).Span(1, 2).Align(giu.FlexAlignEnd, giu.FlexAlignStart),
+				giu.GridCell(giu.Button("d").Size(20, 20)).At(0, 2).Span(2, 1).Align(giu.FlexAlignStart, giu.FlexAlignEnd),
+			).Columns(giu.GridPx(100), giu.GridFr(1), giu.GridFr(2)).Gap(10, 5).Size(360, 0),
+		}
+	})
+
+	origin := h.MustFind("a").Rect.Min
+
+	b := h.MustFind("b").Rect
+	assert.Equal(t, image.Pt(origin.X+190, origin.Y), image.Pt(b.Max.X, b.Min.Y), "cell not aligned to the end of the 1fr column")
+
+	c := h.MustFind("c").Rect
+	assert.Equal(t, image.Pt(origin.X+190, origin.Y+25), image.Pt(c.Max.X, c.Min.Y), "cell spanning two columns misplaced")
+
+	d := h.MustFind("d").Rect
+	assert.Equal(t, image.Pt(origin.X+200, origin.Y+45), image.Pt(d.Min.X, d.Max.Y), "cell spanning two rows misplaced")
+}
@@ -0,0 +1,57 @@
+package giu
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGridTracks(t *testing.T) {
+	tracks, err := ParseGridTracks("100px 1fr auto 2.5fr 30")
+	if assert.NoError(t, err, "unexpected error") {
+		assert.Equal(t, []GridTrack{GridPx(100), GridFr(1), GridAuto(), GridFr(2.5), GridPx(30)}, tracks, "unexpected tracks")
+		assert.Equal(t, "2.5fr", tracks[3].String(), "unexpected string")
+	}
+
+	_, err = ParseGridTracks("1em")
+	assert.Error(t, err, "unknown unit accepted")
+}
+
+func TestGridTracks(t *testing.T) {
+	tracks := []GridTrack{GridPx(50), GridAuto(), GridFr(1), GridFr(3)}
+
+	offsets, sizes := gridTracks(tracks, 250, 10, []float32{0, 30, 0, 0})
+	assert.Equal(t, []float32{50, 30, 35, 105}, sizes, "unexpected sizes")
+	assert.Equal(t, []float32{0, 60, 100, 145}, offsets, "unexpected offsets")
+
+	_, sizes = gridTracks(tracks, -1, 10, []float32{0, 30, 20, 40})
+	assert.Equal(t, []float32{50, 30, 20, 40}, sizes, "fr tracks of unknown size should be auto")
+
+	offset, size := gridSpan(offsets, []float32{50, 30, 35, 105}, 10, 1, 3)
+	assert.Equal(t, float32(60), offset, "unexpected span offset")
+	assert.Equal(t, float32(75), size, "unexpected span size")
+}
+
+func TestGridPlace(t *testing.T) {
+	cells := []*GridCellWidget{
+		GridCell().At(0, 1),
+		GridCell(),
+		GridCell().Span(1, 2),
+		GridCell().Span(2, 1),
+		GridCell(),
+	}
+
+	assert.Equal(t, []image.Rectangle{
+		image.Rect(1, 0, 2, 1),
+		image.Rect(0, 0, 1, 1),
+		image.Rect(0, 1, 2, 2),
+		image.Rect(0, 2, 1, 4),
+		image.Rect(1, 2, 2, 3),
+	}, gridPlace(cells, 2), "unexpected placement")
+
+	assert.Equal(t, []image.Rectangle{
+		image.Rect(0, 0, 1, 1),
+		image.Rect(1, 0, 2, 2),
+	}, gridPlace([]*GridCellWidget{GridCell().At(-1, -2), GridCell().At(-3, 5).Span(2, 1)}, 2), "negative position not clamped")
+}
@@ -0,0 +1,579 @@
+package giu
+
+import (
+	"image"
+	"sync"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// headlessDefaultDeltaTime is a time (in seconds) that passes between two headless frames.
+// It is constant in order to keep results reproducible.
+const headlessDefaultDeltaTime = 1.0 / 60
+
+var _ GIUBackend = &HeadlessBackend{}
+
+// HeadlessBackend is a GIUBackend that doesn't open any window and doesn't need a GPU.
+// It is intended to be used in automated tests (e.g. on CI machines without a display).
+//
+// Frames are computed by imgui exactly like with GLFWBackend, however nothing is
+// presented on the screen. Instead, the draw data of the last frame can be read
+// by LastFrame.
+//
+// Usage:
+//
+//	b := giu.NewHeadlessBackend()
+//	wnd := giu.NewMasterWindowWithBackend(b, "test", 800, 600, 0)
+//	b.SetFrameLimit(2)
+//	wnd.Run(loop) // renders 2 frames and returns
+//	b.Click(giu.MouseButtonLeft, 20, 30)
+//	b.Step(1) // renders one more frame of loop
+type HeadlessBackend struct {
+	afterCreateContext,
+	beforeDestroyContext,
+	beforeRender,
+	afterRender func()
+
+	loop func()
+
+	title         string
+	width, height int
+	posX, posY    int
+	bgColor       imgui.Vec4
+	targetFPS     uint
+	shouldClose   bool
+	contentScale  float32
+	deltaTime     float32
+
+	frameLimit int
+	frameCount int
+
+	dropCallback       backend.DropCallback
+	closeCallback      backend.WindowCloseCallback
+	keyCallback        backend.KeyCallback
+	sizeChangeCallback backend.SizeChangeCallback
+
+	textures      map[imgui.TextureID]*image.RGBA
+	nextTextureID imgui.TextureID
+
+	lastFrame *FrameDrawData
+
+	m *sync.Mutex
+}
+
+// NewHeadlessBackend creates a new instance of HeadlessBackend.
+func NewHeadlessBackend() *HeadlessBackend {
+	return &HeadlessBackend{
+		contentScale:  1,
+		deltaTime:     headlessDefaultDeltaTime,
+		textures:      make(map[imgui.TextureID]*image.RGBA),
+		nextTextureID: 1,
+		m:             &sync.Mutex{},
+	}
+}
+
+// SetFrameLimit sets how many frames Run will render before returning.
+// 0 (default) means that Run returns after SetShouldClose(true) is called (e.g. from the loop).
+func (b *HeadlessBackend) SetFrameLimit(frames int) *HeadlessBackend {
+	b.frameLimit = frames
+	return b
+}
+
+// SetDeltaTime sets a (fake) time in seconds, that passes between two frames.
+// Default is 1/60s.
+func (b *HeadlessBackend) SetDeltaTime(seconds float32) *HeadlessBackend {
+	b.deltaTime = seconds
+	return b
+}
+
+// SetContentScale sets a value returned by ContentScale. Default is 1.
+func (b *HeadlessBackend) SetContentScale(scale float32) *HeadlessBackend {
+	b.contentScale = scale
+	return b
+}
+
+// FrameCount returns number of frames rendered so far.
+func (b *HeadlessBackend) FrameCount() int {
+	return b.frameCount
+}
+
+// SetAfterCreateContextHook implements backend.Backend interface.
+func (b *HeadlessBackend) SetAfterCreateContextHook(hook func()) {
+	b.afterCreateContext = hook
+}
+
+// SetBeforeDestroyContextHook implements backend.Backend interface.
+func (b *HeadlessBackend) SetBeforeDestroyContextHook(hook func()) {
+	b.beforeDestroyContext = hook
+}
+
+// SetBeforeRenderHook implements backend.Backend interface.
+func (b *HeadlessBackend) SetBeforeRenderHook(hook func()) {
+	b.beforeRender = hook
+}
+
+// SetAfterRenderHook implements backend.Backend interface.
+func (b *HeadlessBackend) SetAfterRenderHook(hook func()) {
+	b.afterRender = hook
+}
+
+// SetBgColor implements backend.Backend interface.
+func (b *HeadlessBackend) SetBgColor(col imgui.Vec4) {
+	b.bgColor = col
+}
+
+// BgColor returns background color set by SetBgColor.
+func (b *HeadlessBackend) BgColor() imgui.Vec4 {
+	return b.bgColor
+}
+
+// Run implements backend.Backend interface.
+// It renders frames until SetShouldClose(true) is called or frame limit is reached.
+// See also SetFrameLimit and Step.
+func (b *HeadlessBackend) Run(loop func()) {
+	b.loop = loop
+	b.shouldClose = false
+
+	for rendered := 0; !b.shouldClose && (b.frameLimit == 0 || rendered < b.frameLimit); rendered++ {
+		b.frame()
+	}
+}
+
+// Destroy calls before-destroy-context hook (e.g. destroys implot context).
+// Unlike other backends, HeadlessBackend doesn't do it at the end of Run,
+// so that Step can be used afterwards.
+func (b *HeadlessBackend) Destroy() {
+	if b.beforeDestroyContext != nil {
+		b.beforeDestroyContext()
+	}
+}
+
+// Step renders n frames of the loop passed to the last Run call.
+// It allows to interact with UI (e.g. by Click or Type) after Run returns.
+func (b *HeadlessBackend) Step(n int) {
+	Assert(b.loop != nil, "HeadlessBackend", "Step", "no loop to step; call Run first")
+
+	if b.loop == nil {
+		return
+	}
+
+	for range n {
+		b.frame()
+	}
+}
+
+// Refresh implements backend.Backend interface.
+// Headless frames are rendered on demand, so this is a noop.
+func (b *HeadlessBackend) Refresh() {
+	// noop
+}
+
+// GetWindowPos implements backend.Backend interface.
+func (b *HeadlessBackend) GetWindowPos() (x, y int32) {
+	return int32(b.posX), int32(b.posY)
+}
+
+// SetWindowPos implements backend.Backend interface.
+func (b *HeadlessBackend) SetWindowPos(x, y int) {
+	b.posX, b.posY = x, y
+}
+
+// SetWindowSize implements backend.Backend interface.
+func (b *HeadlessBackend) SetWindowSize(width, height int) {
+	b.width, b.height = width, height
+
+	if b.sizeChangeCallback != nil {
+		b.sizeChangeCallback(width, height)
+	}
+}
+
+// SetWindowSizeLimits implements backend.Backend interface.
+func (b *HeadlessBackend) SetWindowSizeLimits(_, _, _, _ int) {
+	// noop
+}
+
+// SetWindowTitle implements backend.Backend interface.
+func (b *HeadlessBackend) SetWindowTitle(title string) {
+	b.title = title
+}
+
+// DisplaySize implements backend.Backend interface.
+func (b *HeadlessBackend) DisplaySize() (width, height int32) {
+	return int32(b.width), int32(b.height)
+}
+
+// SetShouldClose implements backend.Backend interface.
+func (b *HeadlessBackend) SetShouldClose(v bool) {
+	b.shouldClose = v
+}
+
+// ContentScale implements backend.Backend interface.
+func (b *HeadlessBackend) ContentScale() (xScale, yScale float32) {
+	return b.contentScale, b.contentScale
+}
+
+// SetTargetFPS implements backend.Backend interface.
+// Headless backend doesn't sleep between frames, so the value is only stored.
+func (b *HeadlessBackend) SetTargetFPS(fps uint) {
+	b.targetFPS = fps
+}
+
+// SetDropCallback implements backend.Backend interface.
+func (b *HeadlessBackend) SetDropCallback(cb backend.DropCallback) {
+	b.dropCallback = cb
+}
+
+// SetCloseCallback implements backend.Backend interface.
+func (b *HeadlessBackend) SetCloseCallback(cb backend.WindowCloseCallback) {
+	b.closeCallback = cb
+}
+
+// SetKeyCallback implements backend.Backend interface.
+func (b *HeadlessBackend) SetKeyCallback(cb backend.KeyCallback) {
+	b.keyCallback = cb
+}
+
+// SetSizeChangeCallback implements backend.Backend interface.
+func (b *HeadlessBackend) SetSizeChangeCallback(cb backend.SizeChangeCallback) {
+	b.sizeChangeCallback = cb
+}
+
+// SetWindowFlags implements backend.Backend interface.
+func (b *HeadlessBackend) SetWindowFlags(_ MasterWindowFlags, _ int) {
+	// noop
+}
+
+// SetIcons implements backend.Backend interface.
+func (b *HeadlessBackend) SetIcons(_ ...image.Image) {
+	// noop
+}
+
+// SetSwapInterval implements backend.Backend interface.
+func (b *HeadlessBackend) SetSwapInterval(_ MasterWindowFlags) error {
+	return nil
+}
+
+// SetCursorPos implements backend.Backend interface.
+func (b *HeadlessBackend) SetCursorPos(x, y float64) {
+	b.MoveMouse(float32(x), float32(y))
+}
+
+// SetInputMode implements backend.Backend interface.
+func (b *HeadlessBackend) SetInputMode(_, _ MasterWindowFlags) {
+	// noop
+}
+
+// CreateWindow implements backend.Backend interface.
+func (b *HeadlessBackend) CreateWindow(title string, width, height int) {
+	b.title = title
+	b.width, b.height = width, height
+
+	// we upload textures by ourselves (see processTextures)
+	io := imgui.CurrentIO()
+	io.SetBackendFlags(io.BackendFlags() | imgui.BackendFlagsRendererHasTextures)
+
+	if b.afterCreateContext != nil {
+		b.afterCreateContext()
+	}
+}
+
+// CreateTexture implements backend.TextureManager interface.
+// pixels are expected to be in RGBA format.
+func (b *HeadlessBackend) CreateTexture(pixels unsafe.Pointer, width, height int) imgui.TextureRef {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	copy(img.Pix, unsafe.Slice((*byte)(pixels), len(img.Pix)))
+
+	return b.CreateTextureRgba(img, width, height)
+}
+
+// CreateTextureRgba implements backend.TextureManager interface.
+func (b *HeadlessBackend) CreateTextureRgba(img *image.RGBA, _, _ int) imgui.TextureRef {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	id := b.nextTextureID
+	b.nextTextureID++
+	b.textures[id] = img
+
+	return *imgui.NewTextureRefTextureID(id)
+}
+
+// DeleteTexture implements backend.TextureManager interface.
+func (b *HeadlessBackend) DeleteTexture(ref imgui.TextureRef) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	delete(b.textures, ref.TexID())
+}
+
+// Texture returns pixels of a texture created by this backend (including font atlas).
+// It returns nil if there is no such a texture.
+func (b *HeadlessBackend) Texture(id imgui.TextureID) *image.RGBA {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.textures[id]
+}
+
+// TextureCount returns number of textures currently alive in the backend.
+func (b *HeadlessBackend) TextureCount() int {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return len(b.textures)
+}
+
+// LastFrame returns draw data generated by the last rendered frame or nil if no frame was rendered yet.
+func (b *HeadlessBackend) LastFrame() *FrameDrawData {
+	return b.lastFrame
+}
+
+// Input simulation.
+// NOTE: events are queued in imgui.IO and processed in the next frame.
+
+// MoveMouse moves mouse cursor to (x, y).
+func (b *HeadlessBackend) MoveMouse(x, y float32) {
+	imgui.CurrentIO().AddMousePosEvent(x, y)
+}
+
+// MouseButton presses (down = true) or releases mouse button.
+func (b *HeadlessBackend) MouseButton(button MouseButton, down bool) {
+	imgui.CurrentIO().AddMouseButtonEvent(int32(button), down)
+}
+
+// Click moves mouse to (x, y) and clicks button there.
+// Since imgui processes only one mouse event per frame, moving, pressing and releasing are
+// split into separated frames (Click renders two frames on its own; release is processed in the next one).
+func (b *HeadlessBackend) Click(button MouseButton, x, y float32) {
+	b.MoveMouse(x, y)
+	b.Step(1)
+	b.MouseButton(button, true)
+	b.Step(1)
+	b.MouseButton(button, false)
+}
+
+// Scroll simulates mouse wheel.
+func (b *HeadlessBackend) Scroll(x, y float32) {
+	imgui.CurrentIO().AddMouseWheelEvent(x, y)
+}
+
+// KeyEvent sends a key event to both imgui and the key callback (used by InputHandler).
+func (b *HeadlessBackend) KeyEvent(key Key, mod Modifier, action Action) {
+	io := imgui.CurrentIO()
+	down := action != Release
+
+	for m, imguiMod := range map[Modifier]imgui.Key{
+		ModControl: imgui.ModCtrl,
+		ModShift:   imgui.ModShift,
+		ModAlt:     imgui.ModAlt,
+		ModSuper:   imgui.ModSuper,
+	} {
+		if mod&m != 0 {
+			io.AddKeyEvent(imguiMod, down)
+		}
+	}
+
+	io.AddKeyEvent(imgui.Key(key), down)
+
+	if b.keyCallback != nil {
+		b.keyCallback(int(glfwKeyFromKey(key)), 0, int(action), int(mod))
+	}
+}
+
+// KeyPress presses and releases key with mod.
+// Like Click, it renders one frame between press and release.
+func (b *HeadlessBackend) KeyPress(key Key, mod Modifier) {
+	b.KeyEvent(key, mod, Press)
+	b.Step(1)
+	b.KeyEvent(key, mod, Release)
+}
+
+// Type sends text input (as if it was typed on the keyboard).
+func (b *HeadlessBackend) Type(text string) {
+	imgui.CurrentIO().AddInputCharactersUTF8(text)
+}
+
+// Drop simulates dropping files into the window.
+func (b *HeadlessBackend) Drop(paths ...string) {
+	if b.dropCallback != nil {
+		b.dropCallback(paths)
+	}
+}
+
+// Close simulates user's attempt to close the window.
+func (b *HeadlessBackend) Close() {
+	b.shouldClose = true
+
+	if b.closeCallback != nil {
+		b.closeCallback()
+	}
+}
+
+func (b *HeadlessBackend) frame() {
+	io := imgui.CurrentIO()
+	io.SetDisplaySize(imgui.Vec2{X: float32(b.width), Y: float32(b.height)})
+	io.SetDisplayFramebufferScale(imgui.Vec2{X: b.contentScale, Y: b.contentScale})
+	io.SetDeltaTime(b.deltaTime)
+
+	if b.beforeRender != nil {
+		b.beforeRender()
+	}
+
+	imgui.NewFrame()
+	b.loop()
+	imgui.Render()
+
+	drawData := imgui.CurrentDrawData()
+	b.processTextures(drawData)
+	b.lastFrame = newFrameDrawData(drawData)
+	b.frameCount++
+
+	if b.afterRender != nil {
+		b.afterRender()
+	}
+}
+
+// processTextures does what renderer backends do with imgui's managed textures (e.g. font atlas).
+func (b *HeadlessBackend) processTextures(drawData *imgui.DrawData) {
+	for _, tex := range drawData.Textures().Slice() {
+		switch tex.Status() {
+		case imgui.TextureStatusWantCreate, imgui.TextureStatusWantUpdates:
+			width, height := int(tex.Width()), int(tex.Height())
+			img := image.NewRGBA(image.Rect(0, 0, width, height))
+			src := unsafe.Slice((*byte)(unsafe.Pointer(tex.Pixels())), width*height*int(tex.BytesPerPixel()))
+
+			if tex.Format() == imgui.TextureFormatAlpha8 {
+				for i, a := range src {
+					img.Pix[i*4], img.Pix[i*4+1], img.Pix[i*4+2], img.Pix[i*4+3] = 255, 255, 255, a
+				}
+			} else {
+				copy(img.Pix, src)
+			}
+
+			b.m.Lock()
+
+			id := tex.TexID()
+			if tex.Status() == imgui.TextureStatusWantCreate {
+				id = b.nextTextureID
+				b.nextTextureID++
+			}
+
+			b.textures[id] = img
+
+			b.m.Unlock()
+
+			tex.SetTexID(id)
+			tex.SetStatus(imgui.TextureStatusOK)
+		case imgui.TextureStatusWantDestroy:
+			b.m.Lock()
+			delete(b.textures, tex.TexID())
+			b.m.Unlock()
+
+			tex.SetTexID(0)
+			tex.SetStatus(imgui.TextureStatusDestroyed)
+		}
+	}
+}
+
+// FrameDrawData is a Go copy of imgui.DrawData.
+// Unlike imgui.DrawData it stays valid after the next frame is rendered.
+type FrameDrawData struct {
+	DisplayPos       imgui.Vec2
+	DisplaySize      imgui.Vec2
+	FramebufferScale imgui.Vec2
+	Lists            []FrameDrawList
+}
+
+// FrameDrawList is a Go copy of imgui.DrawList.
+type FrameDrawList struct {
+	Vertices []FrameVertex
+	Indices  []uint32
+	Commands []FrameDrawCmd
+}
+
+// FrameVertex is a single vertex of FrameDrawList.
+type FrameVertex struct {
+	Pos imgui.Vec2
+	UV  imgui.Vec2
+	// Col is a color in imgui's packed format (0xAABBGGRR).
+	Col uint32
+}
+
+// FrameDrawCmd is a single draw command of FrameDrawList.
+type FrameDrawCmd struct {
+	ClipRect  imgui.Vec4
+	TextureID imgui.TextureID
+	VtxOffset uint32
+	IdxOffset uint32
+	ElemCount uint32
+}
+
+// newFrameDrawData copies imgui's draw data.
+func newFrameDrawData(drawData *imgui.DrawData) *FrameDrawData {
+	result := &FrameDrawData{
+		DisplayPos:       drawData.DisplayPos(),
+		DisplaySize:      drawData.DisplaySize(),
+		FramebufferScale: drawData.FramebufferScale(),
+	}
+
+	vertexSize, vertexOffsetPos, vertexOffsetUV, vertexOffsetCol := imgui.VertexBufferLayout()
+	indexSize := imgui.IndexBufferLayout()
+
+	for _, list := range drawData.CommandLists() {
+		var l FrameDrawList
+
+		vertexBuffer, vertexCount := list.GetVertexBuffer()
+		l.Vertices = make([]FrameVertex, vertexCount)
+
+		for i := range l.Vertices {
+			v := unsafe.Add(vertexBuffer, i*vertexSize)
+			l.Vertices[i] = FrameVertex{
+				Pos: *(*imgui.Vec2)(unsafe.Add(v, vertexOffsetPos)),
+				UV:  *(*imgui.Vec2)(unsafe.Add(v, vertexOffsetUV)),
+				Col: *(*uint32)(unsafe.Add(v, vertexOffsetCol)),
+			}
+		}
+
+		indexBuffer, indexCount := list.GetIndexBuffer()
+		l.Indices = make([]uint32, indexCount)
+
+		for i := range l.Indices {
+			idx := unsafe.Add(indexBuffer, i*indexSize)
+			if indexSize == 2 {
+				l.Indices[i] = uint32(*(*uint16)(idx))
+			} else {
+				l.Indices[i] = *(*uint32)(idx)
+			}
+		}
+
+		for _, cmd := range list.Commands() {
+			if cmd.HasUserCallback() {
+				continue
+			}
+
+			l.Commands = append(l.Commands, FrameDrawCmd{
+				ClipRect:  cmd.ClipRect(),
+				TextureID: cmd.TexID(),
+				VtxOffset: cmd.VtxOffset(),
+				IdxOffset: cmd.IdxOffset(),
+				ElemCount: cmd.ElemCount(),
+			})
+		}
+
+		result.Lists = append(result.Lists, l)
+	}
+
+	return result
+}
+
+// Screenshot rasterizes the last frame (see FrameDrawData.Rasterize).
+// It returns nil if no frame was rendered yet.
+func (b *HeadlessBackend) Screenshot() *image.RGBA {
+	if b.lastFrame == nil {
+		return nil
+	}
+
+	return b.lastFrame.Rasterize(Vec4ToRGBA(b.bgColor), b.Texture)
+}
@@ -0,0 +1,69 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HeadlessBackend_Run(t *testing.T) {
+	b := NewHeadlessBackend().SetFrameLimit(3)
+	wnd := NewMasterWindowWithBackend(b, "headless", 320, 240, 0)
+
+	frames := 0
+
+	wnd.Run(func() {
+		frames++
+
+		SingleWindow().Layout(Label("hello"))
+	})
+
+	assert.Equal(t, 3, frames, "unexpected number of frames rendered")
+	assert.Equal(t, 3, b.FrameCount(), "unexpected frame count")
+
+	frame := b.LastFrame()
+	if assert.NotNil(t, frame, "no draw data generated") {
+		assert.NotEmpty(t, frame.Lists, "no draw lists generated")
+		assert.InDelta(t, float32(320), frame.DisplaySize.X, 0, "unexpected display size")
+	}
+}
+
+func Test_HeadlessBackend_Click(t *testing.T) {
+	b := NewHeadlessBackend().SetFrameLimit(2)
+	wnd := NewMasterWindowWithBackend(b, "headless", 320, 240, 0)
+
+	clicked := false
+
+	wnd.Run(func() {
+		SingleWindow().Layout(
+			Button("Click me").Size(100, 50).OnClick(func() {
+				clicked = true
+			}),
+		)
+	})
+
+	x, y := GetWindowPadding()
+	b.Click(MouseButtonLeft, x+10, y+10)
+	b.Step(1)
+
+	assert.True(t, clicked, "button wasn't clicked")
+}
+
+func Test_HeadlessBackend_KeyEvent(t *testing.T) {
+	b := NewHeadlessBackend().SetFrameLimit(1)
+	wnd := NewMasterWindowWithBackend(b, "headless", 320, 240, 0)
+
+	triggered := false
+
+	wnd.RegisterKeyboardShortcuts(WindowShortcut{
+		Key:      KeyS,
+		Modifier: ModControl,
+		Callback: func() { triggered = true },
+	})
+
+	wnd.Run(func() {})
+
+	b.KeyPress(KeyS, ModControl)
+
+	assert.True(t, triggered, "shortcut wasn't triggered")
+}
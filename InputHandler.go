@@ -1,8 +1,17 @@
 package giu
 
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
 // input manager is used to register a keyboard shortcuts in an app.
 
 // Shortcut represents a keyboard shortcut.
+// Shortcuts are triggered on key press only and after key bindings (see KeyBinding),
+// so a key binding with the same keys (or a chord starting with them) takes priority.
 type Shortcut struct {
 	Key      Key
 	Modifier Modifier
@@ -43,18 +52,150 @@ type InputHandler interface {
 	Handle(Key, Modifier, Action)
 }
 
+// KeyStroke is a key pressed with modifiers.
+type KeyStroke struct {
+	Key      Key
+	Modifier Modifier
+}
+
+// KeyTrigger is the key action triggering a KeyBinding.
+type KeyTrigger byte
+
+// key triggers.
+const (
+	TriggerPress KeyTrigger = iota
+	TriggerRelease
+	TriggerRepeat
+)
+
+func (t KeyTrigger) action() Action {
+	switch t {
+	case TriggerRelease:
+		return Release
+	case TriggerRepeat:
+		return Repeat
+	default:
+		return Press
+	}
+}
+
+// KeyBinding binds a callback to a key stroke or to a chord (a sequence of strokes, e.g. Ctrl+K Ctrl+S).
+type KeyBinding struct {
+	// Name (optional) names the command, e.g. "file.save".
+	Name string
+	// Keys are strokes which need to be pressed one after another (within ChordTimeout).
+	Keys []KeyStroke
+	// Scope is the name of the scope (see ShortcutScope) the binding is active in ("" means everywhere).
+	// Bindings of inner scopes take priority over the outer ones.
+	Scope string
+	// Trigger is the action of the last key triggering the binding.
+	// NOTE: only TriggerPress works with chords.
+	Trigger  KeyTrigger
+	Callback func()
+}
+
+// ShortcutConflict describes two key bindings which can't be both triggered.
+// Shortcuts (see RegisterKeyboardShortcuts) are reported as unnamed key bindings.
+type ShortcutConflict struct {
+	First, Second KeyBinding
+	// Prefix is true if keys of one of them start the chord of the other one.
+	// If they are in the same scope, keys of First start the chord of Second, so Second is never triggered.
+	// Otherwise, their keys are the same and Second (registered later) takes priority.
+	Prefix bool
+	// Shadowed is true if Second takes priority over First, because it is in an inner scope
+	// (e.g. a scope overrides global bindings) or First is a Shortcut (they are handled after key bindings).
+	// First isn't triggered while the scope of Second is active.
+	Shadowed bool
+}
+
+// ChordTimeout is the time to press the next stroke of a chord.
+var ChordTimeout = time.Second
+
+// KeyBindingHandler is an InputHandler supporting KeyBindings (the default one does).
+type KeyBindingHandler interface {
+	InputHandler
+	// RegisterKeyBindings adds bindings to the handler.
+	RegisterKeyBindings(...KeyBinding)
+	// RegisterNamedShortcuts registers shortcuts as key bindings named by the map keys, so their keys
+	// may be changed by a Keymap (Key and Modifier are the default ones). Bindings with the same names are replaced.
+	// Window shortcuts (see LocalShortcut) are removed by UnregisterWindowShortcuts.
+	RegisterNamedShortcuts(shortcuts map[string]Shortcut)
+	// UnregisterKeyBindings removes bindings with specified names.
+	UnregisterKeyBindings(names ...string)
+	// KeyBindings returns registered bindings.
+	KeyBindings() []KeyBinding
+	// Conflicts returns pairs of conflicting bindings.
+	Conflicts() []ShortcutConflict
+	// SetKeymap replaces keys of named bindings by the keys from keymap (see LoadKeymap).
+	SetKeymap(Keymap) error
+	// Keymap returns shortcuts of all named bindings (see SaveKeymap).
+	Keymap() Keymap
+	// Rebind changes keys of named bindings (nil keys unbind them).
+	Rebind(name string, keys []KeyStroke)
+	// ResetKeys restores the default keys of named bindings.
+	ResetKeys(name string)
+}
+
+// KeyBindings returns the input handler if it supports KeyBindings (nil otherwise).
+func (c *GIUContext) KeyBindings() KeyBindingHandler {
+	h, ok := c.InputHandler.(KeyBindingHandler)
+	Assert(ok, "GIUContext", "KeyBindings", "input handler %T doesn't support key bindings", c.InputHandler)
+
+	if !ok {
+		return nil
+	}
+
+	return h
+}
+
+// windowShortcutScope is the scope of named window shortcuts (see WindowWidget.RegisterNamedShortcuts).
+const windowShortcutScope = "\x00window"
+
+// registerNamedShortcuts registers s in Context.InputHandler (without names if it doesn't support key bindings).
+func registerNamedShortcuts(s map[string]WindowShortcut, kind ShortcutType) {
+	shortcuts := make(map[string]Shortcut, len(s))
+	for name, shortcut := range s {
+		shortcuts[name] = Shortcut{
+			Key:      shortcut.Key,
+			Modifier: shortcut.Modifier,
+			Callback: shortcut.Callback,
+			IsGlobal: kind,
+		}
+	}
+
+	if h := keyBindingHandler(); h != nil {
+		h.RegisterNamedShortcuts(shortcuts)
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(shortcuts)) {
+		Context.InputHandler.RegisterKeyboardShortcuts(shortcuts[name])
+	}
+}
+
 // --- Default implementation of giu input manager ---
 
-var _ InputHandler = &inputHandler{}
+var _ KeyBindingHandler = &inputHandler{}
 
 func newInputHandler() *inputHandler {
 	return &inputHandler{
-		shortcuts: make(map[keyCombo]*callbacks),
+		shortcuts:    make(map[keyCombo]*callbacks),
+		keymap:       make(map[string][]KeyStroke),
+		activeScopes: activeShortcutScopes,
 	}
 }
 
 type inputHandler struct {
 	shortcuts map[keyCombo]*callbacks
+
+	bindings []KeyBinding
+	// keymap overrides keys of named bindings
+	keymap map[string][]KeyStroke
+	// pending are strokes of a chord being pressed
+	pending      []KeyStroke
+	pendingSince time.Time
+	// activeScopes returns names of active scopes (the innermost first)
+	activeScopes func() []string
 }
 
 func (i *inputHandler) RegisterKeyboardShortcuts(s ...Shortcut) {
@@ -76,13 +217,195 @@ func (i *inputHandler) RegisterKeyboardShortcuts(s ...Shortcut) {
 	}
 }
 
+func (i *inputHandler) RegisterNamedShortcuts(shortcuts map[string]Shortcut) {
+	for _, name := range slices.Sorted(maps.Keys(shortcuts)) {
+		shortcut := shortcuts[name]
+		binding := KeyBinding{
+			Name:     name,
+			Keys:     []KeyStroke{{shortcut.Key, shortcut.Modifier}},
+			Callback: shortcut.Callback,
+		}
+
+		if !shortcut.IsGlobal {
+			binding.Scope = windowShortcutScope
+		}
+
+		idx := slices.IndexFunc(i.bindings, func(b KeyBinding) bool {
+			return b.Name == binding.Name && b.Scope == binding.Scope
+		})
+
+		if idx < 0 {
+			i.bindings = append(i.bindings, binding)
+			continue
+		}
+
+		i.bindings[idx] = binding
+	}
+}
+
 func (i *inputHandler) UnregisterWindowShortcuts() {
 	for _, s := range i.shortcuts {
 		s.window = nil
 	}
+
+	i.bindings = slices.DeleteFunc(i.bindings, func(b KeyBinding) bool {
+		return b.Scope == windowShortcutScope
+	})
+}
+
+func (i *inputHandler) RegisterKeyBindings(b ...KeyBinding) {
+	i.bindings = append(i.bindings, b...)
+}
+
+func (i *inputHandler) UnregisterKeyBindings(names ...string) {
+	i.bindings = slices.DeleteFunc(i.bindings, func(b KeyBinding) bool {
+		return slices.Contains(names, b.Name)
+	})
+}
+
+func (i *inputHandler) KeyBindings() []KeyBinding {
+	return slices.Clone(i.bindings)
+}
+
+func (i *inputHandler) Conflicts() (result []ShortcutConflict) {
+	shortcuts := i.shortcutBindings()
+	bindings := append(shortcuts, i.bindings...)
+
+	for n, first := range bindings {
+		firstKeys := i.keysOf(first)
+		if len(firstKeys) == 0 {
+			continue
+		}
+
+		for m := n + 1; m < len(bindings); m++ {
+			second := bindings[m]
+			secondKeys := i.keysOf(second)
+
+			if first.Trigger != second.Trigger || len(secondKeys) == 0 {
+				continue
+			}
+
+			conflict := ShortcutConflict{
+				First:  first,
+				Second: second,
+				Prefix: isChordPrefix(firstKeys, secondKeys) || isChordPrefix(secondKeys, firstKeys),
+			}
+
+			if !conflict.Prefix && !slices.Equal(firstKeys, secondKeys) {
+				continue
+			}
+
+			switch {
+			case n < len(shortcuts) && m >= len(shortcuts):
+				conflict.Shadowed = true
+			case scopeDepth(first.Scope) != scopeDepth(second.Scope):
+				conflict.Shadowed = true
+				if scopeDepth(first.Scope) > scopeDepth(second.Scope) {
+					conflict.First, conflict.Second = second, first
+				}
+			case first.Scope != second.Scope:
+				// scopes may be nested either way (or never active at the same time)
+				continue
+			case isChordPrefix(secondKeys, firstKeys):
+				conflict.First, conflict.Second = second, first
+			}
+
+			result = append(result, conflict)
+		}
+	}
+
+	return result
+}
+
+// shortcutBindings returns shortcuts registered by RegisterKeyboardShortcuts as unnamed key bindings.
+func (i *inputHandler) shortcutBindings() []KeyBinding {
+	var result []KeyBinding
+
+	for combo, cb := range i.shortcuts {
+		keys := []KeyStroke{{combo.key, combo.modifier}}
+
+		if cb.global != nil {
+			result = append(result, KeyBinding{Keys: keys, Callback: cb.global})
+		}
+
+		if cb.window != nil {
+			result = append(result, KeyBinding{Keys: keys, Scope: windowShortcutScope, Callback: cb.window})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b KeyBinding) int {
+		return cmp.Or(
+			cmp.Compare(a.Keys[0].Key, b.Keys[0].Key),
+			cmp.Compare(a.Keys[0].Modifier, b.Keys[0].Modifier),
+			cmp.Compare(scopeDepth(a.Scope), scopeDepth(b.Scope)),
+		)
+	})
+
+	return result
+}
+
+// scopeDepth returns the nesting level of scope. Bindings of deeper scopes take priority.
+func scopeDepth(scope string) int {
+	switch scope {
+	case "":
+		return 0
+	case windowShortcutScope:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (i *inputHandler) SetKeymap(keymap Keymap) error {
+	parsed, err := keymap.parse()
+	if err != nil {
+		return err
+	}
+
+	i.keymap = parsed
+
+	return nil
+}
+
+func (i *inputHandler) Keymap() Keymap {
+	result := make(Keymap)
+
+	for _, b := range i.bindings {
+		if _, ok := result[b.Name]; b.Name != "" && !ok {
+			result[b.Name] = FormatShortcut(i.keysOf(b))
+		}
+	}
+
+	return result
+}
+
+func (i *inputHandler) Rebind(name string, keys []KeyStroke) {
+	i.keymap[name] = slices.Clone(keys)
+}
+
+func (i *inputHandler) ResetKeys(name string) {
+	delete(i.keymap, name)
+}
+
+// keysOf returns keys of b (from the keymap if b is named).
+func (i *inputHandler) keysOf(b KeyBinding) []KeyStroke {
+	if keys, ok := i.keymap[b.Name]; ok && b.Name != "" {
+		return keys
+	}
+
+	return b.Keys
 }
 
 func (i *inputHandler) Handle(key Key, mod Modifier, a Action) {
+	// keys are captured by KeymapEditor
+	if Context != nil && Context.capturingKeys {
+		return
+	}
+
+	if i.handleKeyBindings(key, mod, a, time.Now()) {
+		return
+	}
+
 	if a != Press {
 		return
 	}
@@ -109,3 +432,89 @@ type callbacks struct {
 	global func()
 	window func()
 }
+
+// handleKeyBindings triggers a key binding. It returns true if the stroke was consumed
+// (a binding was triggered or a chord is being pressed).
+func (i *inputHandler) handleKeyBindings(key Key, mod Modifier, a Action, now time.Time) bool {
+	if len(i.bindings) == 0 || isModifierKey(key) {
+		return false
+	}
+
+	stroke := KeyStroke{key, mod &^ (ModCapsLock | ModNumLock)}
+
+	var scopes []string
+	if i.activeScopes != nil {
+		scopes = i.activeScopes()
+	}
+
+	scopes = append(scopes, windowShortcutScope, "")
+
+	if a != Press {
+		for _, scope := range scopes {
+			if b, ok := i.findBinding(scope, []KeyStroke{stroke}, a); ok {
+				b.Callback()
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(i.pending) > 0 {
+		chord := append(i.pending, stroke)
+		expired := now.Sub(i.pendingSince) > ChordTimeout
+		i.pending = nil
+
+		if !expired && i.matchChord(chord, scopes, now) {
+			return true
+		}
+	}
+
+	return i.matchChord([]KeyStroke{stroke}, scopes, now)
+}
+
+// matchChord triggers a binding with keys chord or, if chord starts a longer one, waits for the next stroke.
+func (i *inputHandler) matchChord(chord []KeyStroke, scopes []string, now time.Time) bool {
+	for _, scope := range scopes {
+		if b, ok := i.findBinding(scope, chord, Press); ok {
+			b.Callback()
+			return true
+		}
+
+		for _, b := range i.bindings {
+			if b.Scope == scope && b.Trigger == TriggerPress && isChordPrefix(chord, i.keysOf(b)) {
+				i.pending, i.pendingSince = chord, now
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// findBinding returns the binding of scope with keys triggered by a (the last registered one).
+func (i *inputHandler) findBinding(scope string, keys []KeyStroke, a Action) (KeyBinding, bool) {
+	for n := len(i.bindings) - 1; n >= 0; n-- {
+		b := i.bindings[n]
+		if b.Scope == scope && b.Trigger.action() == a && b.Callback != nil && slices.Equal(i.keysOf(b), keys) {
+			return b, true
+		}
+	}
+
+	return KeyBinding{}, false
+}
+
+// isChordPrefix returns true if chord is a shorter beginning of keys.
+func isChordPrefix(chord, keys []KeyStroke) bool {
+	return len(chord) < len(keys) && slices.Equal(chord, keys[:len(chord)])
+}
+
+func isModifierKey(key Key) bool {
+	switch key {
+	case KeyLeftShift, KeyRightShift, KeyLeftControl, KeyRightControl,
+		KeyLeftAlt, KeyRightAlt, KeyLeftSuper, KeyRightSuper:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,28 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_KeyBindingChord(t *testing.T) {
+	saved := false
+
+	h := giutest.New(t, 400, 300)
+	giu.Context.KeyBindings().RegisterKeyBindings(giu.KeyBinding{
+		Name:     "file.saveAll",
+		Keys:     []giu.KeyStroke{{Key: giu.KeyK, Modifier: giu.ModControl}, {Key: giu.KeyS, Modifier: giu.ModControl}},
+		Callback: func() { saved = true },
+	})
+
+	h.Run(1, func() giu.Layout { return nil })
+	h.PressKey(giu.KeyK, giu.ModControl)
+	assert.False(t, saved, "chord was triggered by its first stroke")
+
+	h.PressKey(giu.KeyS, giu.ModControl)
+	assert.True(t, saved, "chord wasn't triggered")
+}
@@ -2,6 +2,7 @@ package giu
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -98,3 +99,186 @@ func Test_InputHandler_Handle(t *testing.T) {
 	a.True(shortcut1, "Shortcut 1 was not handled, but should be.")
 	a.True(shortcut2, "Shortcut 2 was not handled, but should be.")
 }
+
+func Test_InputHandler_KeyBindings_Chord(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+	i.activeScopes = nil
+
+	var saved, single int
+
+	i.RegisterKeyBindings(
+		KeyBinding{Name: "save", Keys: []KeyStroke{{KeyK, ModControl}, {KeyS, ModControl}}, Callback: func() { saved++ }},
+		KeyBinding{Name: "single", Keys: []KeyStroke{{KeyS, ModControl}}, Callback: func() { single++ }},
+	)
+
+	now := time.Now()
+
+	a.True(i.handleKeyBindings(KeyK, ModControl, Press, now), "first stroke of a chord should be consumed")
+	a.False(i.handleKeyBindings(KeyLeftControl, ModControl, Press, now), "modifier keys should be ignored")
+	a.True(i.handleKeyBindings(KeyS, ModControl, Press, now))
+	a.Equal(1, saved, "chord wasn't triggered")
+	a.Equal(0, single, "single stroke was triggered inside of a chord")
+
+	a.True(i.handleKeyBindings(KeyS, ModControl, Press, now))
+	a.Equal(1, single, "single stroke wasn't triggered")
+
+	// timeout
+	i.handleKeyBindings(KeyK, ModControl, Press, now)
+	i.handleKeyBindings(KeyS, ModControl, Press, now.Add(ChordTimeout+time.Millisecond))
+	a.Equal(1, saved, "chord was triggered after timeout")
+	a.Equal(2, single, "stroke after timeout wasn't triggered")
+
+	// broken chord
+	i.handleKeyBindings(KeyK, ModControl, Press, now)
+	a.False(i.handleKeyBindings(KeyA, ModNone, Press, now), "unbound stroke was consumed")
+	a.Empty(i.pending, "chord wasn't broken")
+}
+
+func Test_InputHandler_KeyBindings_Trigger(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+	i.activeScopes = nil
+
+	var pressed, released, repeated int
+
+	i.RegisterKeyBindings(
+		KeyBinding{Keys: []KeyStroke{{KeyA, ModNone}}, Callback: func() { pressed++ }},
+		KeyBinding{Keys: []KeyStroke{{KeyA, ModNone}}, Trigger: TriggerRelease, Callback: func() { released++ }},
+		KeyBinding{Keys: []KeyStroke{{KeyA, ModNone}}, Trigger: TriggerRepeat, Callback: func() { repeated++ }},
+	)
+
+	now := time.Now()
+	i.handleKeyBindings(KeyA, ModNone, Press, now)
+	i.handleKeyBindings(KeyA, ModNone, Repeat, now)
+	i.handleKeyBindings(KeyA, ModNone, Repeat, now)
+	i.handleKeyBindings(KeyA, ModCapsLock, Release, now)
+
+	a.Equal(1, pressed)
+	a.Equal(2, repeated)
+	a.Equal(1, released)
+}
+
+func Test_InputHandler_KeyBindings_Scopes(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+
+	scopes := []string{}
+	i.activeScopes = func() []string { return scopes }
+
+	var called string
+
+	bind := func(scope string) KeyBinding {
+		return KeyBinding{Keys: []KeyStroke{{KeyF, ModControl}}, Scope: scope, Callback: func() { called = scope }}
+	}
+
+	i.RegisterKeyBindings(bind(""), bind("window"), bind("child"))
+
+	i.handleKeyBindings(KeyF, ModControl, Press, time.Now())
+	a.Equal("", called, "global binding should be triggered without active scopes")
+
+	scopes = []string{"window"}
+	i.handleKeyBindings(KeyF, ModControl, Press, time.Now())
+	a.Equal("window", called)
+
+	scopes = []string{"child", "window"}
+	i.handleKeyBindings(KeyF, ModControl, Press, time.Now())
+	a.Equal("child", called, "the innermost scope should take priority")
+}
+
+func Test_InputHandler_KeyBindings_Unregister(t *testing.T) {
+	i := newInputHandler()
+	i.RegisterKeyBindings(
+		KeyBinding{Name: "a", Keys: []KeyStroke{{KeyA, ModNone}}},
+		KeyBinding{Name: "b", Keys: []KeyStroke{{KeyB, ModNone}}},
+	)
+
+	i.UnregisterKeyBindings("a")
+
+	bindings := i.KeyBindings()
+	if assert.Len(t, bindings, 1) {
+		assert.Equal(t, "b", bindings[0].Name)
+	}
+}
+
+func Test_InputHandler_Conflicts(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+
+	ctrlK := KeyStroke{KeyK, ModControl}
+	ctrlS := KeyStroke{KeyS, ModControl}
+
+	i.RegisterKeyBindings(
+		KeyBinding{Name: "save", Keys: []KeyStroke{ctrlS}},
+		KeyBinding{Name: "saveAll", Keys: []KeyStroke{ctrlK, ctrlS}},
+		KeyBinding{Name: "kill", Keys: []KeyStroke{ctrlK}},
+		KeyBinding{Name: "store", Keys: []KeyStroke{ctrlS}},
+		KeyBinding{Name: "editorSave", Keys: []KeyStroke{ctrlS}, Scope: "editor"},
+		KeyBinding{Name: "releaseSave", Keys: []KeyStroke{ctrlS}, Trigger: TriggerRelease},
+	)
+
+	type conflict struct {
+		first, second    string
+		prefix, shadowed bool
+	}
+
+	conflicts := make([]conflict, 0)
+	for _, c := range i.Conflicts() {
+		conflicts = append(conflicts, conflict{c.First.Name, c.Second.Name, c.Prefix, c.Shadowed})
+	}
+
+	a.Equal([]conflict{
+		{"save", "store", false, false},
+		{"save", "editorSave", false, true},
+		{"kill", "saveAll", true, false},
+		{"store", "editorSave", false, true},
+	}, conflicts, "unexpected conflicts")
+}
+
+func Test_InputHandler_ConflictsWithShortcuts(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+
+	i.RegisterKeyboardShortcuts(
+		Shortcut{Key: KeyK, Modifier: ModControl, Callback: func() {}, IsGlobal: GlobalShortcut},
+		Shortcut{Key: KeyK, Modifier: ModControl, Callback: func() {}, IsGlobal: LocalShortcut},
+		Shortcut{Key: KeyQ, Modifier: ModControl, Callback: func() {}, IsGlobal: GlobalShortcut},
+	)
+	i.RegisterKeyBindings(KeyBinding{Name: "saveAll", Keys: []KeyStroke{{KeyK, ModControl}, {KeyS, ModControl}}})
+
+	conflicts := i.Conflicts()
+	if !a.Len(conflicts, 3) {
+		return
+	}
+
+	// the window shortcut overrides the global one
+	a.Equal("", conflicts[0].First.Scope)
+	a.Equal(windowShortcutScope, conflicts[0].Second.Scope)
+	a.True(conflicts[0].Shadowed)
+	a.False(conflicts[0].Prefix)
+
+	// the chord swallows both shortcuts
+	for _, c := range conflicts[1:] {
+		a.Empty(c.First.Name)
+		a.Equal("saveAll", c.Second.Name)
+		a.True(c.Shadowed)
+		a.True(c.Prefix)
+	}
+}
+
+func Test_shortcutScopes(t *testing.T) {
+	s := &shortcutScopes{}
+
+	s.begin("window", true)
+	s.begin("child", true)
+	s.end()
+	s.begin("other child", false)
+	s.end()
+	s.end()
+	s.begin("other window", false)
+	s.end()
+	s.endFrame()
+
+	assert.Equal(t, []string{"child", "window"}, s.active)
+	assert.Empty(t, s.stack)
+}
@@ -0,0 +1,292 @@
+package giu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"reflect"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"golang.org/x/image/colornames"
+)
+
+// inspectorNode is a widget recorded by the inspector.
+type inspectorNode struct {
+	typeName string
+	// id is empty if the widget has no ID
+	id   ID
+	rect image.Rectangle
+	// itemID is ID of the last imgui item submitted while the widget was built
+	itemID imgui.ID
+	// ownItem is false if the widget didn't submit an imgui item (e.g. Row) or the item has no ID (e.g. Label)
+	ownItem   bool
+	duplicate bool
+	children  []*inspectorNode
+}
+
+var _ BuildObserver = &inspector{}
+
+// inspector records the tree of widgets built by Layout (see DebugInspector).
+type inspector struct {
+	frame int32
+	// trees of the current and of the last complete frame
+	current, last []*inspectorNode
+	stack         []*inspectorNode
+	// duplicates is the number of widgets with duplicate IDs in the last frame
+	duplicates int
+	// paused is true while the inspector window is built (its widgets aren't recorded)
+	paused bool
+}
+
+// BeforeBuild implements BuildObserver.
+func (p *inspector) BeforeBuild(w Widget) {
+	if p.paused {
+		return
+	}
+
+	p.sync(imgui.FrameCount())
+	p.begin(w)
+}
+
+// AfterBuild implements BuildObserver.
+func (p *inspector) AfterBuild(_ Widget) {
+	if p.paused {
+		return
+	}
+
+	rectMin, rectMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+	p.end(image.Rect(int(rectMin.X), int(rectMin.Y), int(rectMax.X), int(rectMax.Y)), imgui.ItemID())
+}
+
+// sync starts recording a new tree if frame has changed.
+func (p *inspector) sync(frame int32) {
+	if frame == p.frame {
+		return
+	}
+
+	p.frame = frame
+	p.last, p.current = p.current, nil
+	p.stack = p.stack[:0]
+	p.duplicates = markDuplicateIDs(p.last)
+}
+
+func (p *inspector) begin(w Widget) {
+	node := &inspectorNode{typeName: fmt.Sprintf("%T", w)}
+	node.id, _ = WidgetID(w)
+
+	if len(p.stack) > 0 {
+		parent := p.stack[len(p.stack)-1]
+		parent.children = append(parent.children, node)
+	} else {
+		p.current = append(p.current, node)
+	}
+
+	p.stack = append(p.stack, node)
+}
+
+// end finishes the node of the widget being built. rect and itemID describe the last imgui item.
+func (p *inspector) end(rect image.Rectangle, itemID imgui.ID) {
+	if len(p.stack) == 0 {
+		return
+	}
+
+	node := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+
+	node.rect, node.itemID = rect, itemID
+	node.ownItem = node.id != "" && itemID != 0
+
+	if len(node.children) == 0 {
+		return
+	}
+
+	// the last item belongs to a child (e.g. for Row), so use the area of all children
+	if last := node.children[len(node.children)-1]; last.itemID == itemID {
+		node.ownItem = false
+		node.rect = last.rect
+
+		for _, child := range node.children {
+			node.rect = node.rect.Union(child.rect)
+		}
+	}
+}
+
+// markDuplicateIDs marks nodes which submitted imgui items with the same ID. It returns the number of marked nodes.
+// NOTE: imgui IDs depend on the ID stack (e.g. window), so widgets with the same ID in different windows don't clash.
+func markDuplicateIDs(roots []*inspectorNode) (count int) {
+	byID := make(map[imgui.ID][]*inspectorNode)
+
+	var walk func(nodes []*inspectorNode)
+	walk = func(nodes []*inspectorNode) {
+		for _, node := range nodes {
+			if node.ownItem {
+				byID[node.itemID] = append(byID[node.itemID], node)
+			}
+
+			walk(node.children)
+		}
+	}
+
+	walk(roots)
+
+	for _, nodes := range byID {
+		if len(nodes) < 2 {
+			continue
+		}
+
+		for _, node := range nodes {
+			node.duplicate = true
+		}
+
+		count += len(nodes)
+	}
+
+	return count
+}
+
+// WidgetID returns ID of w (the first non-empty of its id, label or text fields).
+// Widgets keep them unexported, so it is meant for debugging and testing tools (e.g. DebugInspector, giutest).
+func WidgetID(w Widget) (ID, bool) {
+	v := reflect.ValueOf(w)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	for _, fieldName := range []string{"id", "label", "text"} {
+		if f := v.FieldByName(fieldName); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return ID(f.String()), true
+		}
+	}
+
+	return "", false
+}
+
+var _ Widget = &DebugInspectorWidget{}
+
+// DebugInspectorWidget is a window showing the tree of widgets built in the last frame:
+// their types, IDs, translated labels and screen rectangles.
+// Hovering a row highlights the widget on the screen. Widgets which submitted imgui items
+// with the same ID (e.g. reused "##id" labels) are marked, as imgui confuses them
+// (e.g. only one of buttons with the same ID responds to clicks).
+//
+// NOTE: only widgets built by Layout (and RowWidget) are recorded (see BuildObserver).
+type DebugInspectorWidget struct {
+	open *bool
+}
+
+// DebugInspector creates a new DebugInspectorWidget. It is a window, so build it in the loop function:
+//
+//	giu.DebugInspector().Build()
+func DebugInspector() *DebugInspectorWidget {
+	return &DebugInspectorWidget{}
+}
+
+// IsOpen sets a pointer to the visibility of the inspector (it adds close button to it).
+// Widgets aren't recorded while the inspector is closed.
+func (d *DebugInspectorWidget) IsOpen(open *bool) *DebugInspectorWidget {
+	d.open = open
+	return d
+}
+
+// Build implements Widget interface.
+func (d *DebugInspectorWidget) Build() {
+	if d.open != nil && !*d.open {
+		if Context.inspector != nil {
+			Context.RemoveBuildObserver(Context.inspector)
+			Context.inspector = nil
+		}
+
+		return
+	}
+
+	if Context.inspector == nil {
+		Context.inspector = &inspector{}
+		Context.AddBuildObserver(Context.inspector)
+	}
+
+	p := Context.inspector
+	p.sync(imgui.FrameCount())
+
+	p.paused = true
+	defer func() {
+		p.paused = false
+	}()
+
+	var hovered *inspectorNode
+
+	var summary Widget = Label("No duplicate IDs")
+	if p.duplicates > 0 {
+		summary = Style().SetColor(StyleColorText, colornames.Orangered).To(
+			Labelf("%d widgets with duplicate IDs", p.duplicates),
+		)
+	}
+
+	Window("Widget inspector##giuInspector").
+		IsOpen(d.open).
+		Size(500, 400).
+		Flags(WindowFlagsNoSavedSettings).
+		Layout(
+			summary,
+			Separator(),
+			inspectorTree(p.last, &hovered),
+		)
+
+	if hovered != nil && !hovered.rect.Empty() {
+		canvas := &Canvas{DrawList: imgui.ForegroundDrawListViewportPtr()}
+		canvas.AddRectFilled(hovered.rect.Min, hovered.rect.Max, color.RGBA{R: 255, G: 255, B: 0, A: 64}, 0, DrawFlagsNone)
+		canvas.AddRect(hovered.rect.Min, hovered.rect.Max, colornames.Orange, 0, DrawFlagsNone, 2)
+	}
+}
+
+// inspectorTree creates tree nodes for nodes. hovered is set to the node under mouse cursor.
+func inspectorTree(nodes []*inspectorNode, hovered **inspectorNode) Layout {
+	result := make(Layout, len(nodes))
+
+	for i, node := range nodes {
+		text := node.typeName
+		if node.id != "" {
+			label := strings.Split(Context.PrepareString(string(node.id)), "##")[0]
+			text += fmt.Sprintf("  ID: %q  label: %q", node.id, label)
+		}
+
+		text += fmt.Sprintf("  %v", node.rect)
+
+		var row Widget = Label(text)
+		if node.duplicate {
+			row = Style().SetColor(StyleColorText, colornames.Orangered).To(Label("DUPLICATE ID  " + text))
+		}
+
+		flags := TreeNodeFlagsSpanAvailWidth
+		if len(node.children) == 0 {
+			flags |= TreeNodeFlagsLeaf
+		}
+
+		result[i] = TreeNode(fmt.Sprintf("##giuInspector%d", i)).
+			Flags(flags).
+			Event(func() {
+				if IsItemHovered() {
+					*hovered = node
+				}
+
+				imgui.SameLine()
+
+				row.Build()
+
+				if IsItemHovered() {
+					*hovered = node
+				}
+			}).
+			Layout(inspectorTree(node.children, hovered))
+	}
+
+	return result
+}
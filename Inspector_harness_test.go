@@ -0,0 +1,36 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_DebugInspector(t *testing.T) {
+	clicked := 0
+
+	h := giutest.New(t, 600, 400)
+	h.Run(3, func() giu.Layout {
+		return giu.Layout{
+			giu.Row(
+				giu.Button("Run").OnClick(func() { clicked++ }),
+				giu.Button("Stop##dup"),
+				giu.Button("Stop##dup"),
+			),
+			giu.DebugInspector(),
+		}
+	})
+
+	// only the two "Stop##dup" buttons clash ("Run" and the inspector's own widgets don't)
+	h.MustFind("2 widgets with duplicate IDs")
+
+	_, ok := h.FindByLabel("No duplicate IDs")
+	assert.False(t, ok, "duplicate IDs not reported")
+
+	h.Click("Run")
+
+	assert.Equal(t, 1, clicked, "button not clickable with the inspector shown")
+}
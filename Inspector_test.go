@@ -0,0 +1,60 @@
+package giu
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WidgetIDOf(t *testing.T) {
+	id, ok := WidgetID(&ButtonWidget{id: "Save##0"})
+	assert.True(t, ok, "button has ID")
+	assert.Equal(t, ID("Save##0"), id, "unexpected ID")
+
+	id, ok = WidgetID(&LabelWidget{label: "text"})
+	assert.True(t, ok, "label has ID")
+	assert.Equal(t, ID("text"), id, "unexpected ID")
+
+	_, ok = WidgetID(&RowWidget{})
+	assert.False(t, ok, "row has no ID")
+
+	_, ok = WidgetID((*ButtonWidget)(nil))
+	assert.False(t, ok, "nil widget has no ID")
+}
+
+func Test_InspectorTree(t *testing.T) {
+	p := &inspector{}
+	p.sync(1)
+
+	// Row{Button("A"), Button("B")}, Label, Button("A") (the same imgui ID)
+	p.begin(&RowWidget{})
+	p.begin(&ButtonWidget{id: "A"})
+	p.end(image.Rect(0, 0, 10, 10), 1)
+	p.begin(&ButtonWidget{id: "B"})
+	p.end(image.Rect(20, 0, 30, 10), 2)
+	p.end(image.Rect(20, 0, 30, 10), 2)
+	p.begin(&LabelWidget{label: "text"})
+	p.end(image.Rect(0, 20, 30, 30), 0)
+	p.begin(&ButtonWidget{id: "A"})
+	p.end(image.Rect(0, 40, 10, 50), 1)
+
+	assert.Empty(t, p.last, "tree reported before the frame ended")
+
+	p.sync(2)
+
+	if assert.Len(t, p.last, 3, "unexpected number of roots") {
+		row := p.last[0]
+		assert.Equal(t, "*giu.RowWidget", row.typeName, "unexpected type")
+		assert.Len(t, row.children, 2, "unexpected number of children")
+		assert.False(t, row.ownItem, "row has no own item")
+		assert.Equal(t, image.Rect(0, 0, 30, 10), row.rect, "row should cover its children")
+
+		assert.True(t, row.children[0].duplicate, "duplicate ID not marked")
+		assert.False(t, row.children[1].duplicate, "unique ID marked")
+		assert.False(t, p.last[1].duplicate, "label without imgui ID marked")
+		assert.True(t, p.last[2].duplicate, "duplicate ID not marked")
+	}
+
+	assert.Equal(t, 2, p.duplicates, "unexpected number of duplicates")
+}
@@ -0,0 +1,157 @@
+package giu
+
+import (
+	"context"
+	"sync"
+)
+
+// Invoke queues f to be called on the UI (main) thread before the next frame and requests the frame (see Update).
+// It is safe to call from any goroutine, so it is a way to modify data used by widgets
+// without synchronization. Functions are called in order of Invoke calls.
+// NOTE: remember to call it after NewMasterWindow!
+func Invoke(f func()) {
+	Assert(Context != nil && Context.invokeQueue != nil, "", "Invoke", "you need to call Invoke after giu.NewMasterWindow call!")
+
+	if Context == nil || Context.invokeQueue == nil {
+		return
+	}
+
+	Context.invokeM.Lock()
+	Context.invokeQueue.Add(f)
+	Context.invokeM.Unlock()
+
+	Update()
+}
+
+// runInvoked calls functions queued by Invoke. Functions queued meanwhile are left for the next frame.
+func (c *GIUContext) runInvoked() {
+	c.invokeM.Lock()
+
+	queued := make([]func(), 0, c.invokeQueue.Length())
+	for c.invokeQueue.Length() > 0 {
+		f, ok := c.invokeQueue.Remove().(func())
+		Assert(ok, "MasterWindow", "Run", "processing invoke requests: wrong type of request")
+
+		if !ok {
+			continue
+		}
+
+		queued = append(queued, f)
+	}
+
+	c.invokeM.Unlock()
+
+	for _, f := range queued {
+		f()
+	}
+}
+
+// Future is a result of a function run by InvokeResult or Async.
+// It is resolved on the UI thread, so its Result may be read in Build without synchronization.
+type Future[T any] struct {
+	m         *sync.Mutex
+	done      chan struct{}
+	value     T
+	err       error
+	callbacks []func(T, error)
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{
+		m:    &sync.Mutex{},
+		done: make(chan struct{}),
+	}
+}
+
+// resolve sets the result and calls callbacks registered by Then. Must be called on the UI thread.
+func (f *Future[T]) resolve(value T, err error) {
+	f.m.Lock()
+	f.value, f.err = value, err
+	callbacks := f.callbacks
+	f.callbacks = nil
+	close(f.done)
+	f.m.Unlock()
+
+	for _, cb := range callbacks {
+		cb(value, err)
+	}
+}
+
+// Done returns a channel closed when the future is resolved.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone returns true if the future is resolved.
+func (f *Future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result returns the value and the error of resolved future (zero values if it isn't resolved yet).
+// It doesn't block, so it is meant to be called in Build.
+func (f *Future[T]) Result() (value T, err error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	return f.value, f.err
+}
+
+// Wait blocks until the future is resolved and returns its result.
+// NOTE: do not call it on the UI thread (e.g. in Build) - it would never be resolved.
+func (f *Future[T]) Wait() (value T, err error) {
+	<-f.done
+	return f.Result()
+}
+
+// Then registers cb to be called on the UI thread when the future is resolved
+// (if it is already resolved, cb is invoked before the next frame).
+func (f *Future[T]) Then(cb func(value T, err error)) *Future[T] {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if !f.IsDone() {
+		f.callbacks = append(f.callbacks, cb)
+		return f
+	}
+
+	value, err := f.value, f.err
+
+	Invoke(func() {
+		cb(value, err)
+	})
+
+	return f
+}
+
+// InvokeResult is like Invoke, but returns a Future resolved with the value returned by f.
+func InvokeResult[T any](f func() T) *Future[T] {
+	result := newFuture[T]()
+
+	Invoke(func() {
+		result.resolve(f(), nil)
+	})
+
+	return result
+}
+
+// Async runs f in a new goroutine. When it returns, the future is resolved on the UI thread
+// and a new frame is requested, so widgets may show the result.
+// ctx is passed to f; cancel it to stop the work (f is responsible for checking it).
+func Async[T any](ctx context.Context, f func(ctx context.Context) (T, error)) *Future[T] {
+	result := newFuture[T]()
+
+	go func() {
+		value, err := f(ctx)
+
+		Invoke(func() {
+			result.resolve(value, err)
+		})
+	}()
+
+	return result
+}
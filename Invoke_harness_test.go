@@ -0,0 +1,45 @@
+package giu_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_Invoke(t *testing.T) {
+	status := "loading"
+
+	h := giutest.New(t, 400, 300)
+	h.Run(1, func() giu.Layout {
+		return giu.Layout{giu.Label(status)}
+	})
+
+	future := giu.Async(context.Background(), func(context.Context) (string, error) {
+		return "loaded", nil
+	}).Then(func(value string, _ error) {
+		status = value
+	})
+
+	// wait for the goroutine; the result is applied on the UI thread
+	for !future.IsDone() {
+		h.Step(1)
+	}
+
+	h.Step(1)
+
+	_, ok := h.FindByLabel("loaded")
+	assert.True(t, ok, "result of Async not shown")
+
+	result := giu.InvokeResult(func() int { return 42 })
+	assert.False(t, result.IsDone(), "InvokeResult resolved before the next frame")
+
+	h.Step(1)
+
+	value, err := result.Result()
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 42, value, "unexpected result")
+}
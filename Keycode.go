@@ -139,120 +139,121 @@ const (
 	KeyUnknown        = Key(-1)
 )
 
+// glfwKeys maps glfw key codes to giu keys.
 // refer glfw3.h.
-func keyFromGLFWKey(k glfwbackend.GLFWKey) Key {
-	data := map[glfwbackend.GLFWKey]Key{
-		glfwbackend.GLFWKeySpace:        KeySpace,
-		glfwbackend.GLFWKeyApostrophe:   KeyApostrophe,
-		glfwbackend.GLFWKeyComma:        KeyComma,
-		glfwbackend.GLFWKeyMinus:        KeyMinus,
-		glfwbackend.GLFWKeyPeriod:       KeyPeriod,
-		glfwbackend.GLFWKeySlash:        KeySlash,
-		glfwbackend.GLFWKey0:            Key0,
-		glfwbackend.GLFWKey1:            Key1,
-		glfwbackend.GLFWKey2:            Key2,
-		glfwbackend.GLFWKey3:            Key3,
-		glfwbackend.GLFWKey4:            Key4,
-		glfwbackend.GLFWKey5:            Key5,
-		glfwbackend.GLFWKey6:            Key6,
-		glfwbackend.GLFWKey7:            Key7,
-		glfwbackend.GLFWKey8:            Key8,
-		glfwbackend.GLFWKey9:            Key9,
-		glfwbackend.GLFWKeySemicolon:    KeySemicolon,
-		glfwbackend.GLFWKeyEqual:        KeyEqual,
-		glfwbackend.GLFWKeyA:            KeyA,
-		glfwbackend.GLFWKeyB:            KeyB,
-		glfwbackend.GLFWKeyC:            KeyC,
-		glfwbackend.GLFWKeyD:            KeyD,
-		glfwbackend.GLFWKeyE:            KeyE,
-		glfwbackend.GLFWKeyF:            KeyF,
-		glfwbackend.GLFWKeyG:            KeyG,
-		glfwbackend.GLFWKeyH:            KeyH,
-		glfwbackend.GLFWKeyI:            KeyI,
-		glfwbackend.GLFWKeyJ:            KeyJ,
-		glfwbackend.GLFWKeyK:            KeyK,
-		glfwbackend.GLFWKeyL:            KeyL,
-		glfwbackend.GLFWKeyM:            KeyM,
-		glfwbackend.GLFWKeyN:            KeyN,
-		glfwbackend.GLFWKeyO:            KeyO,
-		glfwbackend.GLFWKeyP:            KeyP,
-		glfwbackend.GLFWKeyQ:            KeyQ,
-		glfwbackend.GLFWKeyR:            KeyR,
-		glfwbackend.GLFWKeyS:            KeyS,
-		glfwbackend.GLFWKeyT:            KeyT,
-		glfwbackend.GLFWKeyU:            KeyU,
-		glfwbackend.GLFWKeyV:            KeyV,
-		glfwbackend.GLFWKeyW:            KeyW,
-		glfwbackend.GLFWKeyX:            KeyX,
-		glfwbackend.GLFWKeyY:            KeyY,
-		glfwbackend.GLFWKeyZ:            KeyZ,
-		glfwbackend.GLFWKeyLeftBracket:  KeyLeftBracket,
-		glfwbackend.GLFWKeyBackslash:    KeyBackslash,
-		glfwbackend.GLFWKeyRightBracket: KeyRightBracket,
-		glfwbackend.GLFWKeyGraveAccent:  KeyGraveAccent,
-		glfwbackend.GLFWKeyEscape:       KeyEscape,
-		glfwbackend.GLFWKeyEnter:        KeyEnter,
-		glfwbackend.GLFWKeyTab:          KeyTab,
-		glfwbackend.GLFWKeyBackspace:    KeyBackspace,
-		glfwbackend.GLFWKeyInsert:       KeyInsert,
-		glfwbackend.GLFWKeyDelete:       KeyDelete,
-		glfwbackend.GLFWKeyRight:        KeyRight,
-		glfwbackend.GLFWKeyLeft:         KeyLeft,
-		glfwbackend.GLFWKeyDown:         KeyDown,
-		glfwbackend.GLFWKeyUp:           KeyUp,
-		glfwbackend.GLFWKeyPageUp:       KeyPageUp,
-		glfwbackend.GLFWKeyPageDown:     KeyPageDown,
-		glfwbackend.GLFWKeyHome:         KeyHome,
-		glfwbackend.GLFWKeyEnd:          KeyEnd,
-		glfwbackend.GLFWKeyCapsLock:     KeyCapsLock,
-		glfwbackend.GLFWKeyScrollLock:   KeyScrollLock,
-		glfwbackend.GLFWKeyNumLock:      KeyNumLock,
-		glfwbackend.GLFWKeyPrintScreen:  KeyPrintScreen,
-		glfwbackend.GLFWKeyPause:        KeyPause,
-		glfwbackend.GLFWKeyF1:           KeyF1,
-		glfwbackend.GLFWKeyF2:           KeyF2,
-		glfwbackend.GLFWKeyF3:           KeyF3,
-		glfwbackend.GLFWKeyF4:           KeyF4,
-		glfwbackend.GLFWKeyF5:           KeyF5,
-		glfwbackend.GLFWKeyF6:           KeyF6,
-		glfwbackend.GLFWKeyF7:           KeyF7,
-		glfwbackend.GLFWKeyF8:           KeyF8,
-		glfwbackend.GLFWKeyF9:           KeyF9,
-		glfwbackend.GLFWKeyF10:          KeyF10,
-		glfwbackend.GLFWKeyF11:          KeyF11,
-		glfwbackend.GLFWKeyF12:          KeyF12,
-		glfwbackend.GLFWKeyKp0:          KeyNumPad0,
-		glfwbackend.GLFWKeyKp1:          KeyNumPad1,
-		glfwbackend.GLFWKeyKp2:          KeyNumPad2,
-		glfwbackend.GLFWKeyKp3:          KeyNumPad3,
-		glfwbackend.GLFWKeyKp4:          KeyNumPad4,
-		glfwbackend.GLFWKeyKp5:          KeyNumPad5,
-		glfwbackend.GLFWKeyKp6:          KeyNumPad6,
-		glfwbackend.GLFWKeyKp7:          KeyNumPad7,
-		glfwbackend.GLFWKeyKp8:          KeyNumPad8,
-		glfwbackend.GLFWKeyKp9:          KeyNumPad9,
-		glfwbackend.GLFWKeyKpDecimal:    KeyNumPadDecimal,
-		glfwbackend.GLFWKeyKpDivide:     KeyNumPadDivide,
-		glfwbackend.GLFWKeyKpMultiply:   KeyNumPadMultiply,
-		glfwbackend.GLFWKeyKpSubtract:   KeyNumPadSubtract,
-		glfwbackend.GLFWKeyKpAdd:        KeyNumPadAdd,
-		glfwbackend.GLFWKeyKpEnter:      KeyNumPadEnter,
-		glfwbackend.GLFWKeyKpEqual:      KeyNumPadEqual,
-		glfwbackend.GLFWKeyLeftShift:    KeyLeftShift,
-		glfwbackend.GLFWKeyLeftControl:  KeyLeftControl,
-		glfwbackend.GLFWKeyLeftAlt:      KeyLeftAlt,
-		glfwbackend.GLFWKeyLeftSuper:    KeyLeftSuper,
-		glfwbackend.GLFWKeyRightShift:   KeyRightShift,
-		glfwbackend.GLFWKeyRightControl: KeyRightControl,
-		glfwbackend.GLFWKeyRightAlt:     KeyRightAlt,
-		glfwbackend.GLFWKeyRightSuper:   KeyRightSuper,
-		glfwbackend.GLFWKeyMenu:         KeyMenu,
-		glfwbackend.GLFWKeyWorld1:       KeyWorld1,
-		glfwbackend.GLFWKeyWorld2:       KeyWorld2,
-		-1:                              KeyUnknown,
-	}
+var glfwKeys = map[glfwbackend.GLFWKey]Key{
+	glfwbackend.GLFWKeySpace:        KeySpace,
+	glfwbackend.GLFWKeyApostrophe:   KeyApostrophe,
+	glfwbackend.GLFWKeyComma:        KeyComma,
+	glfwbackend.GLFWKeyMinus:        KeyMinus,
+	glfwbackend.GLFWKeyPeriod:       KeyPeriod,
+	glfwbackend.GLFWKeySlash:        KeySlash,
+	glfwbackend.GLFWKey0:            Key0,
+	glfwbackend.GLFWKey1:            Key1,
+	glfwbackend.GLFWKey2:            Key2,
+	glfwbackend.GLFWKey3:            Key3,
+	glfwbackend.GLFWKey4:            Key4,
+	glfwbackend.GLFWKey5:            Key5,
+	glfwbackend.GLFWKey6:            Key6,
+	glfwbackend.GLFWKey7:            Key7,
+	glfwbackend.GLFWKey8:            Key8,
+	glfwbackend.GLFWKey9:            Key9,
+	glfwbackend.GLFWKeySemicolon:    KeySemicolon,
+	glfwbackend.GLFWKeyEqual:        KeyEqual,
+	glfwbackend.GLFWKeyA:            KeyA,
+	glfwbackend.GLFWKeyB:            KeyB,
+	glfwbackend.GLFWKeyC:            KeyC,
+	glfwbackend.GLFWKeyD:            KeyD,
+	glfwbackend.GLFWKeyE:            KeyE,
+	glfwbackend.GLFWKeyF:            KeyF,
+	glfwbackend.GLFWKeyG:            KeyG,
+	glfwbackend.GLFWKeyH:            KeyH,
+	glfwbackend.GLFWKeyI:            KeyI,
+	glfwbackend.GLFWKeyJ:            KeyJ,
+	glfwbackend.GLFWKeyK:            KeyK,
+	glfwbackend.GLFWKeyL:            KeyL,
+	glfwbackend.GLFWKeyM:            KeyM,
+	glfwbackend.GLFWKeyN:            KeyN,
+	glfwbackend.GLFWKeyO:            KeyO,
+	glfwbackend.GLFWKeyP:            KeyP,
+	glfwbackend.GLFWKeyQ:            KeyQ,
+	glfwbackend.GLFWKeyR:            KeyR,
+	glfwbackend.GLFWKeyS:            KeyS,
+	glfwbackend.GLFWKeyT:            KeyT,
+	glfwbackend.GLFWKeyU:            KeyU,
+	glfwbackend.GLFWKeyV:            KeyV,
+	glfwbackend.GLFWKeyW:            KeyW,
+	glfwbackend.GLFWKeyX:            KeyX,
+	glfwbackend.GLFWKeyY:            KeyY,
+	glfwbackend.GLFWKeyZ:            KeyZ,
+	glfwbackend.GLFWKeyLeftBracket:  KeyLeftBracket,
+	glfwbackend.GLFWKeyBackslash:    KeyBackslash,
+	glfwbackend.GLFWKeyRightBracket: KeyRightBracket,
+	glfwbackend.GLFWKeyGraveAccent:  KeyGraveAccent,
+	glfwbackend.GLFWKeyEscape:       KeyEscape,
+	glfwbackend.GLFWKeyEnter:        KeyEnter,
+	glfwbackend.GLFWKeyTab:          KeyTab,
+	glfwbackend.GLFWKeyBackspace:    KeyBackspace,
+	glfwbackend.GLFWKeyInsert:       KeyInsert,
+	glfwbackend.GLFWKeyDelete:       KeyDelete,
+	glfwbackend.GLFWKeyRight:        KeyRight,
+	glfwbackend.GLFWKeyLeft:         KeyLeft,
+	glfwbackend.GLFWKeyDown:         KeyDown,
+	glfwbackend.GLFWKeyUp:           KeyUp,
+	glfwbackend.GLFWKeyPageUp:       KeyPageUp,
+	glfwbackend.GLFWKeyPageDown:     KeyPageDown,
+	glfwbackend.GLFWKeyHome:         KeyHome,
+	glfwbackend.GLFWKeyEnd:          KeyEnd,
+	glfwbackend.GLFWKeyCapsLock:     KeyCapsLock,
+	glfwbackend.GLFWKeyScrollLock:   KeyScrollLock,
+	glfwbackend.GLFWKeyNumLock:      KeyNumLock,
+	glfwbackend.GLFWKeyPrintScreen:  KeyPrintScreen,
+	glfwbackend.GLFWKeyPause:        KeyPause,
+	glfwbackend.GLFWKeyF1:           KeyF1,
+	glfwbackend.GLFWKeyF2:           KeyF2,
+	glfwbackend.GLFWKeyF3:           KeyF3,
+	glfwbackend.GLFWKeyF4:           KeyF4,
+	glfwbackend.GLFWKeyF5:           KeyF5,
+	glfwbackend.GLFWKeyF6:           KeyF6,
+	glfwbackend.GLFWKeyF7:           KeyF7,
+	glfwbackend.GLFWKeyF8:           KeyF8,
+	glfwbackend.GLFWKeyF9:           KeyF9,
+	glfwbackend.GLFWKeyF10:          KeyF10,
+	glfwbackend.GLFWKeyF11:          KeyF11,
+	glfwbackend.GLFWKeyF12:          KeyF12,
+	glfwbackend.GLFWKeyKp0:          KeyNumPad0,
+	glfwbackend.GLFWKeyKp1:          KeyNumPad1,
+	glfwbackend.GLFWKeyKp2:          KeyNumPad2,
+	glfwbackend.GLFWKeyKp3:          KeyNumPad3,
+	glfwbackend.GLFWKeyKp4:          KeyNumPad4,
+	glfwbackend.GLFWKeyKp5:          KeyNumPad5,
+	glfwbackend.GLFWKeyKp6:          KeyNumPad6,
+	glfwbackend.GLFWKeyKp7:          KeyNumPad7,
+	glfwbackend.GLFWKeyKp8:          KeyNumPad8,
+	glfwbackend.GLFWKeyKp9:          KeyNumPad9,
+	glfwbackend.GLFWKeyKpDecimal:    KeyNumPadDecimal,
+	glfwbackend.GLFWKeyKpDivide:     KeyNumPadDivide,
+	glfwbackend.GLFWKeyKpMultiply:   KeyNumPadMultiply,
+	glfwbackend.GLFWKeyKpSubtract:   KeyNumPadSubtract,
+	glfwbackend.GLFWKeyKpAdd:        KeyNumPadAdd,
+	glfwbackend.GLFWKeyKpEnter:      KeyNumPadEnter,
+	glfwbackend.GLFWKeyKpEqual:      KeyNumPadEqual,
+	glfwbackend.GLFWKeyLeftShift:    KeyLeftShift,
+	glfwbackend.GLFWKeyLeftControl:  KeyLeftControl,
+	glfwbackend.GLFWKeyLeftAlt:      KeyLeftAlt,
+	glfwbackend.GLFWKeyLeftSuper:    KeyLeftSuper,
+	glfwbackend.GLFWKeyRightShift:   KeyRightShift,
+	glfwbackend.GLFWKeyRightControl: KeyRightControl,
+	glfwbackend.GLFWKeyRightAlt:     KeyRightAlt,
+	glfwbackend.GLFWKeyRightSuper:   KeyRightSuper,
+	glfwbackend.GLFWKeyMenu:         KeyMenu,
+	glfwbackend.GLFWKeyWorld1:       KeyWorld1,
+	glfwbackend.GLFWKeyWorld2:       KeyWorld2,
+	-1:                              KeyUnknown,
+}
 
-	if v, ok := data[k]; ok {
+func keyFromGLFWKey(k glfwbackend.GLFWKey) Key {
+	if v, ok := glfwKeys[k]; ok {
 		return v
 	}
 
@@ -261,6 +262,18 @@ func keyFromGLFWKey(k glfwbackend.GLFWKey) Key {
 	return 0
 }
 
+// glfwKeyFromKey does the opposite of keyFromGLFWKey.
+// It is used by backends that need to simulate glfw key events.
+func glfwKeyFromKey(k Key) glfwbackend.GLFWKey {
+	for glfwKey, key := range glfwKeys {
+		if key == k {
+			return glfwKey
+		}
+	}
+
+	return -1
+}
+
 // Modifier represents imgui.Modifier.
 type Modifier imgui.Key
 
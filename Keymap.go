@@ -0,0 +1,475 @@
+package giu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/BurntSushi/toml"
+	"golang.org/x/image/colornames"
+)
+
+// keyName is a name of a key used in shortcut strings.
+type keyName struct {
+	key  Key
+	name string
+}
+
+// keyNames are names of keys which may be used in shortcuts (in the order they are polled by KeymapEditor).
+var keyNames = []keyName{
+	{KeyA, "A"}, {KeyB, "B"}, {KeyC, "C"}, {KeyD, "D"}, {KeyE, "E"}, {KeyF, "F"}, {KeyG, "G"},
+	{KeyH, "H"}, {KeyI, "I"}, {KeyJ, "J"}, {KeyK, "K"}, {KeyL, "L"}, {KeyM, "M"}, {KeyN, "N"},
+	{KeyO, "O"}, {KeyP, "P"}, {KeyQ, "Q"}, {KeyR, "R"}, {KeyS, "S"}, {KeyT, "T"}, {KeyU, "U"},
+	{KeyV, "V"}, {KeyW, "W"}, {KeyX, "X"}, {KeyY, "Y"}, {KeyZ, "Z"},
+	{Key0, "0"}, {Key1, "1"}, {Key2, "2"}, {Key3, "3"}, {Key4, "4"},
+	{Key5, "5"}, {Key6, "6"}, {Key7, "7"}, {Key8, "8"}, {Key9, "9"},
+	{KeyF1, "F1"}, {KeyF2, "F2"}, {KeyF3, "F3"}, {KeyF4, "F4"}, {KeyF5, "F5"}, {KeyF6, "F6"},
+	{KeyF7, "F7"}, {KeyF8, "F8"}, {KeyF9, "F9"}, {KeyF10, "F10"}, {KeyF11, "F11"}, {KeyF12, "F12"},
+	{KeySpace, "Space"}, {KeyEnter, "Enter"}, {KeyEscape, "Escape"}, {KeyTab, "Tab"},
+	{KeyBackspace, "Backspace"}, {KeyInsert, "Insert"}, {KeyDelete, "Delete"},
+	{KeyLeft, "Left"}, {KeyRight, "Right"}, {KeyUp, "Up"}, {KeyDown, "Down"},
+	{KeyPageUp, "PageUp"}, {KeyPageDown, "PageDown"}, {KeyHome, "Home"}, {KeyEnd, "End"},
+	{KeyPrintScreen, "PrintScreen"}, {KeyPause, "Pause"},
+	{KeyApostrophe, "'"}, {KeyComma, ","}, {KeyMinus, "-"}, {KeyPeriod, "."}, {KeySlash, "/"},
+	{KeySemicolon, ";"}, {KeyEqual, "="}, {KeyLeftBracket, "["}, {KeyBackslash, "\\"},
+	{KeyRightBracket, "]"}, {KeyGraveAccent, "`"},
+	{KeyNumPad0, "NumPad0"}, {KeyNumPad1, "NumPad1"}, {KeyNumPad2, "NumPad2"}, {KeyNumPad3, "NumPad3"},
+	{KeyNumPad4, "NumPad4"}, {KeyNumPad5, "NumPad5"}, {KeyNumPad6, "NumPad6"}, {KeyNumPad7, "NumPad7"},
+	{KeyNumPad8, "NumPad8"}, {KeyNumPad9, "NumPad9"}, {KeyNumPadDecimal, "NumPadDecimal"},
+	{KeyNumPadDivide, "NumPadDivide"}, {KeyNumPadMultiply, "NumPadMultiply"},
+	{KeyNumPadSubtract, "NumPadSubtract"}, {KeyNumPadAdd, "NumPadAdd"},
+	{KeyNumPadEnter, "NumPadEnter"}, {KeyNumPadEqual, "NumPadEqual"},
+}
+
+// numPadImguiKeys are imgui keys of NumPad keys (their Keys are glfw codes, see Keycode.go).
+var numPadImguiKeys = map[Key]imgui.Key{
+	KeyNumPad0: imgui.KeyKeypad0, KeyNumPad1: imgui.KeyKeypad1, KeyNumPad2: imgui.KeyKeypad2,
+	KeyNumPad3: imgui.KeyKeypad3, KeyNumPad4: imgui.KeyKeypad4, KeyNumPad5: imgui.KeyKeypad5,
+	KeyNumPad6: imgui.KeyKeypad6, KeyNumPad7: imgui.KeyKeypad7, KeyNumPad8: imgui.KeyKeypad8,
+	KeyNumPad9: imgui.KeyKeypad9, KeyNumPadDecimal: imgui.KeyKeypadDecimal,
+	KeyNumPadDivide: imgui.KeyKeypadDivide, KeyNumPadMultiply: imgui.KeyKeypadMultiply,
+	KeyNumPadSubtract: imgui.KeyKeypadSubtract, KeyNumPadAdd: imgui.KeyKeypadAdd,
+	KeyNumPadEnter: imgui.KeyKeypadEnter, KeyNumPadEqual: imgui.KeyKeypadEqual,
+}
+
+// imguiKey returns the imgui key of k. It returns false if imgui doesn't know k (imgui keys start with Tab).
+func imguiKey(k Key) (imgui.Key, bool) {
+	if key, ok := numPadImguiKeys[k]; ok {
+		return key, true
+	}
+
+	return imgui.Key(k), k >= KeyTab
+}
+
+// modifierNames are names of modifiers in the order they are formatted.
+var modifierNames = []struct {
+	mod     Modifier
+	name    string
+	aliases []string
+}{
+	{ModControl, "Ctrl", []string{"control"}},
+	{ModShift, "Shift", nil},
+	{ModAlt, "Alt", []string{"option"}},
+	{ModSuper, "Super", []string{"cmd", "command", "win", "meta"}},
+}
+
+// String formats the stroke like "Ctrl+Shift+S".
+func (s KeyStroke) String() string {
+	var parts []string
+
+	for _, m := range modifierNames {
+		if s.Modifier&m.mod != 0 {
+			parts = append(parts, m.name)
+		}
+	}
+
+	name := fmt.Sprintf("Key(%d)", s.Key)
+
+	for _, k := range keyNames {
+		if k.key == s.Key {
+			name = k.name
+			break
+		}
+	}
+
+	return strings.Join(append(parts, name), "+")
+}
+
+// ParseKeyStroke parses a stroke like "Ctrl+Shift+S" (names are case-insensitive).
+func ParseKeyStroke(s string) (KeyStroke, error) {
+	parts := strings.Split(strings.TrimSpace(s), "+")
+
+	// "Ctrl++" doesn't have a key name, but "+" is typed with Shift+= anyway.
+	if slices.Contains(parts, "") {
+		return KeyStroke{}, fmt.Errorf("invalid shortcut %q: empty key name", s)
+	}
+
+	var result KeyStroke
+
+parts:
+	for _, part := range parts[:len(parts)-1] {
+		for _, m := range modifierNames {
+			if strings.EqualFold(part, m.name) || slices.Contains(m.aliases, strings.ToLower(part)) {
+				result.Modifier |= m.mod
+				continue parts
+			}
+		}
+
+		return KeyStroke{}, fmt.Errorf("invalid shortcut %q: unknown modifier %q", s, part)
+	}
+
+	keyStr := parts[len(parts)-1]
+	for _, k := range keyNames {
+		if strings.EqualFold(k.name, keyStr) {
+			result.Key = k.key
+			return result, nil
+		}
+	}
+
+	if strings.EqualFold(keyStr, "Esc") {
+		result.Key = KeyEscape
+		return result, nil
+	}
+
+	return KeyStroke{}, fmt.Errorf("invalid shortcut %q: unknown key %q", s, keyStr)
+}
+
+// ParseShortcut parses a stroke or a chord of strokes separated by spaces (e.g. "Ctrl+K Ctrl+S").
+// An empty string means no keys.
+func ParseShortcut(s string) ([]KeyStroke, error) {
+	fields := strings.Fields(s)
+	result := make([]KeyStroke, 0, len(fields))
+
+	for _, field := range fields {
+		stroke, err := ParseKeyStroke(field)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, stroke)
+	}
+
+	return result, nil
+}
+
+// FormatShortcut does the opposite of ParseShortcut.
+func FormatShortcut(keys []KeyStroke) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Keymap maps command names (see KeyBinding.Name and KeyBindingHandler.RegisterNamedShortcuts) to shortcuts
+// like "Ctrl+Shift+S" or "Ctrl+K Ctrl+S". An empty shortcut unbinds the command.
+type Keymap map[string]string
+
+// parse parses all shortcuts of the keymap.
+func (k Keymap) parse() (map[string][]KeyStroke, error) {
+	result := make(map[string][]KeyStroke, len(k))
+
+	for name, shortcut := range k {
+		keys, err := ParseShortcut(shortcut)
+		if err != nil {
+			return nil, fmt.Errorf("command %q: %w", name, err)
+		}
+
+		result[name] = keys
+	}
+
+	return result, nil
+}
+
+// LoadKeymap loads a keymap from a JSON file or, if the path ends with ".toml", from a TOML file.
+// In TOML, names of commands may be dotted keys or tables:
+//
+//	[file]
+//	save = "Ctrl+S"
+//	saveAll = "Ctrl+K Ctrl+S"
+//
+// It returns nil keymap if the file doesn't exist. Pass it to KeyBindingHandler.SetKeymap.
+func LoadKeymap(path string) (Keymap, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("loading keymap: %w", err)
+	}
+
+	keymap := make(Keymap)
+
+	if isTOMLPath(path) {
+		err = decodeKeymapTOML(data, keymap)
+	} else {
+		err = json.Unmarshal(data, &keymap)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("decoding keymap from %s: %w", path, err)
+	}
+
+	if _, err := keymap.parse(); err != nil {
+		return nil, fmt.Errorf("decoding keymap from %s: %w", path, err)
+	}
+
+	return keymap, nil
+}
+
+// SaveKeymap saves keymap (e.g. KeyBindingHandler.Keymap()) to a file in format chosen like by LoadKeymap.
+func SaveKeymap(path string, keymap Keymap) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if isTOMLPath(path) {
+		data, err = toml.Marshal(keymap)
+	} else {
+		data, err = json.MarshalIndent(keymap, "", "\t")
+	}
+
+	if err != nil {
+		return fmt.Errorf("encoding keymap: %w", err)
+	}
+
+	const newFileMode = 0o644
+	if err := os.WriteFile(path, data, newFileMode); err != nil {
+		return fmt.Errorf("saving keymap: %w", err)
+	}
+
+	return nil
+}
+
+func isTOMLPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// decodeKeymapTOML decodes a TOML keymap. Names of commands in tables (e.g. [file] or file.save = ...)
+// are joined by dots.
+func decodeKeymapTOML(data []byte, keymap Keymap) error {
+	var table map[string]any
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return err
+	}
+
+	return flattenKeymapTable("", table, keymap)
+}
+
+// flattenKeymapTable adds shortcuts from table (and its subtables) to keymap.
+func flattenKeymapTable(prefix string, table map[string]any, keymap Keymap) error {
+	for key, value := range table {
+		switch value := value.(type) {
+		case string:
+			keymap[prefix+key] = value
+		case map[string]any:
+			if err := flattenKeymapTable(prefix+key+".", value, keymap); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("command %q: expected a string, got %T", prefix+key, value)
+		}
+	}
+
+	return nil
+}
+
+var _ Disposable = &keymapEditorState{}
+
+type keymapEditorState struct {
+	// capturing is the name of the command being rebound ("" if none)
+	capturing string
+	strokes   []KeyStroke
+	lastPress time.Time
+}
+
+// Dispose implements Disposable interface.
+func (s *keymapEditorState) Dispose() {
+	if s.capturing != "" && Context != nil {
+		Context.capturingKeys = false
+	}
+}
+
+var _ Widget = &KeymapEditorWidget{}
+
+// KeymapEditorWidget lists named key bindings (see KeyBinding.Name and KeyBindingHandler.RegisterNamedShortcuts) of Context.InputHandler
+// and lets user rebind them. After clicking a shortcut, the next key press (or a chord of presses) is captured
+// (Escape cancels it). Conflicting bindings are marked.
+// Save the result with SaveKeymap(path, Context.KeyBindings().Keymap()).
+type KeymapEditorWidget struct {
+	id       ID
+	onChange func()
+}
+
+// KeymapEditor creates a new KeymapEditorWidget.
+func KeymapEditor() *KeymapEditorWidget {
+	return &KeymapEditorWidget{
+		id: GenAutoID("KeymapEditor"),
+	}
+}
+
+// ID sets the internal id of the editor.
+func (k *KeymapEditorWidget) ID(id ID) *KeymapEditorWidget {
+	k.id = id
+	return k
+}
+
+// OnChange sets a callback called when a command is rebound (e.g. to save the keymap).
+func (k *KeymapEditorWidget) OnChange(cb func()) *KeymapEditorWidget {
+	k.onChange = cb
+	return k
+}
+
+func (k *KeymapEditorWidget) getState() *keymapEditorState {
+	var state *keymapEditorState
+	if state = GetState[keymapEditorState](Context, k.id); state == nil {
+		state = &keymapEditorState{}
+		SetState(Context, k.id, state)
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (k *KeymapEditorWidget) Build() {
+	handler := Context.KeyBindings()
+	if handler == nil {
+		return
+	}
+
+	state := k.getState()
+	if state.capturing != "" {
+		k.capture(handler, state)
+	}
+
+	conflicting := make(map[string]bool)
+	for _, c := range handler.Conflicts() {
+		conflicting[c.First.Name] = true
+		conflicting[c.Second.Name] = true
+	}
+
+	keymap := handler.Keymap()
+
+	names := make([]string, 0, len(keymap))
+	for name := range keymap {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	rows := make([]*TableRowWidget, len(names))
+
+	for i, name := range names {
+		shortcut := keymap[name]
+		if shortcut == "" {
+			shortcut = "(none)"
+		}
+
+		if state.capturing == name {
+			shortcut = FormatShortcut(state.strokes) + " ..."
+		}
+
+		var nameLabel Widget = Label(name)
+		if conflicting[name] {
+			nameLabel = Style().SetColor(StyleColorText, colornames.Orangered).To(
+				Label(name + " (conflict)"),
+			)
+		}
+
+		rows[i] = TableRow(
+			nameLabel,
+			Button(fmt.Sprintf("%s##%s%s", shortcut, k.id, name)).OnClick(func() {
+				state.capturing, state.strokes = name, nil
+				Context.capturingKeys = true
+			}),
+			Row(
+				Button(fmt.Sprintf("Clear##%s%sClear", k.id, name)).OnClick(func() {
+					handler.Rebind(name, nil)
+					k.changed()
+				}),
+				Button(fmt.Sprintf("Reset##%s%sReset", k.id, name)).OnClick(func() {
+					handler.ResetKeys(name)
+					k.changed()
+				}),
+			),
+		)
+	}
+
+	Table().ID(k.id).
+		Columns(
+			TableColumn("Command"),
+			TableColumn("Shortcut"),
+			TableColumn(""),
+		).
+		Rows(rows...).
+		Build()
+}
+
+// capture appends pressed strokes to state. The chord is finished if no key is pressed for ChordTimeout.
+func (k *KeymapEditorWidget) capture(handler KeyBindingHandler, state *keymapEditorState) {
+	if IsKeyPressed(KeyEscape) {
+		state.capturing, state.strokes = "", nil
+		Context.capturingKeys = false
+
+		return
+	}
+
+	io := Context.IO()
+
+	var mod Modifier
+
+	for _, m := range []struct {
+		down bool
+		mod  Modifier
+	}{
+		{io.KeyCtrl(), ModControl},
+		{io.KeyShift(), ModShift},
+		{io.KeyAlt(), ModAlt},
+		{io.KeySuper(), ModSuper},
+	} {
+		if m.down {
+			mod |= m.mod
+		}
+	}
+
+	for _, kn := range keyNames {
+		key, ok := imguiKey(kn.key)
+		if !ok {
+			continue
+		}
+
+		if imgui.IsKeyPressedBoolV(key, false) {
+			state.strokes = append(state.strokes, KeyStroke{kn.key, mod})
+			state.lastPress = time.Now()
+		}
+	}
+
+	if len(state.strokes) == 0 || time.Since(state.lastPress) < ChordTimeout {
+		// wait for the next stroke
+		Update()
+		return
+	}
+
+	handler.Rebind(state.capturing, state.strokes)
+
+	state.capturing, state.strokes = "", nil
+	Context.capturingKeys = false
+
+	k.changed()
+}
+
+func (k *KeymapEditorWidget) changed() {
+	if k.onChange != nil {
+		k.onChange()
+	}
+}
@@ -0,0 +1,154 @@
+package giu
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseKeyStroke(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected KeyStroke
+		str      string
+	}{
+		{"Ctrl+Shift+S", KeyStroke{KeyS, ModControl | ModShift}, "Ctrl+Shift+S"},
+		{"shift+ctrl+s", KeyStroke{KeyS, ModControl | ModShift}, "Ctrl+Shift+S"},
+		{"Cmd+Alt+F5", KeyStroke{KeyF5, ModSuper | ModAlt}, "Alt+Super+F5"},
+		{"Esc", KeyStroke{KeyEscape, ModNone}, "Escape"},
+		{"Ctrl+-", KeyStroke{KeyMinus, ModControl}, "Ctrl+-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			stroke, err := ParseKeyStroke(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stroke)
+			assert.Equal(t, tt.str, stroke.String())
+		})
+	}
+
+	for _, invalid := range []string{"", "Ctrl+", "Hyper+S", "Ctrl+Foo"} {
+		_, err := ParseKeyStroke(invalid)
+		assert.Error(t, err, "%q should be invalid", invalid)
+	}
+}
+
+func Test_imguiKey(t *testing.T) {
+	for _, kn := range keyNames {
+		_, ok := imguiKey(kn.key)
+		assert.True(t, ok, "%s can't be captured by KeymapEditor", kn.name)
+	}
+
+	key, ok := imguiKey(KeyNumPad5)
+	assert.True(t, ok)
+	assert.Equal(t, imgui.KeyKeypad5, key, "NumPad keys should be mapped to imgui keys")
+}
+
+func Test_ParseShortcut(t *testing.T) {
+	keys, err := ParseShortcut("Ctrl+K  Ctrl+S")
+	require.NoError(t, err)
+	assert.Equal(t, []KeyStroke{{KeyK, ModControl}, {KeyS, ModControl}}, keys)
+	assert.Equal(t, "Ctrl+K Ctrl+S", FormatShortcut(keys))
+
+	keys, err = ParseShortcut("")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func Test_Keymap_SaveLoad(t *testing.T) {
+	keymap := Keymap{
+		"file.save":    "Ctrl+S",
+		"file.saveAll": "Ctrl+K Ctrl+S",
+		"edit.undo":    "",
+	}
+
+	for _, name := range []string{"keymap.json", "keymap.toml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+
+			require.NoError(t, SaveKeymap(path, keymap))
+
+			loaded, err := LoadKeymap(path)
+			require.NoError(t, err)
+			assert.Equal(t, keymap, loaded)
+		})
+	}
+
+	loaded, err := LoadKeymap(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NoError(t, err, "missing file should be ignored")
+	assert.Nil(t, loaded)
+}
+
+func Test_decodeKeymapTOML(t *testing.T) {
+	keymap := make(Keymap)
+	err := decodeKeymapTOML([]byte(`
+# shortcuts
+save = "Ctrl+S" # comment
+"file.open" = 'Ctrl+O'
+edit.undo = "Ctrl+Z"
+"quoted\tname" = "Ctrl+Q"
+
+[view]
+zoomIn = "Ctrl+Equal"
+
+[view.panels]
+files = "Ctrl+1"
+`), keymap)
+
+	require.NoError(t, err)
+	assert.Equal(t, Keymap{
+		"save":              "Ctrl+S",
+		"file.open":         "Ctrl+O",
+		"edit.undo":         "Ctrl+Z",
+		"quoted\tname":      "Ctrl+Q",
+		"view.zoomIn":       "Ctrl+Equal",
+		"view.panels.files": "Ctrl+1",
+	}, keymap)
+
+	for _, invalid := range []string{`save "Ctrl+S"`, `save = Ctrl+S`, `save = "Ctrl+S" x`, `save = 1`, `save = ["Ctrl+S"]`} {
+		assert.Error(t, decodeKeymapTOML([]byte(invalid), make(Keymap)), "%q should be invalid", invalid)
+	}
+}
+
+func Test_InputHandler_Keymap(t *testing.T) {
+	a := assert.New(t)
+	i := newInputHandler()
+	i.activeScopes = nil
+
+	saved := 0
+
+	i.RegisterNamedShortcuts(map[string]Shortcut{
+		"file.save": {Key: KeyS, Modifier: ModControl, Callback: func() { saved++ }, IsGlobal: GlobalShortcut},
+	})
+	// windows register their shortcuts in every frame
+	i.RegisterNamedShortcuts(map[string]Shortcut{"edit.cut": {Key: KeyX, Modifier: ModControl, Callback: func() {}}})
+	i.RegisterNamedShortcuts(map[string]Shortcut{"edit.cut": {Key: KeyX, Modifier: ModControl, Callback: func() {}}})
+
+	a.Equal(Keymap{"file.save": "Ctrl+S", "edit.cut": "Ctrl+X"}, i.Keymap())
+
+	a.Error(i.SetKeymap(Keymap{"file.save": "Ctrl+Foo"}))
+	a.NoError(i.SetKeymap(Keymap{"file.save": "Ctrl+K S"}))
+
+	now := time.Now()
+	i.Handle(KeyS, ModControl, Press)
+	a.Equal(0, saved, "default keys should be replaced by the keymap")
+
+	i.handleKeyBindings(KeyK, ModControl, Press, now)
+	i.handleKeyBindings(KeyS, ModNone, Press, now)
+	a.Equal(1, saved, "keys from keymap weren't handled")
+
+	i.Rebind("file.save", nil)
+	a.Equal("", i.Keymap()["file.save"])
+
+	i.ResetKeys("file.save")
+	i.Handle(KeyS, ModControl, Press)
+	a.Equal(2, saved, "default keys weren't restored")
+
+	i.UnregisterWindowShortcuts()
+	a.Equal(Keymap{"file.save": "Ctrl+S"}, i.Keymap(), "window shortcuts weren't unregistered")
+}
@@ -25,11 +25,43 @@ type Layout []Widget
 func (l Layout) Build() {
 	for _, w := range l {
 		if w != nil {
-			w.Build()
+			buildWidget(w)
 		}
 	}
 }
 
+// BuildObserver is notified about each widget built by Layout (and RowWidget).
+// It is intended for testing and debugging purposes (see giutest package).
+// Use Context.AddBuildObserver to register it.
+type BuildObserver interface {
+	// BeforeBuild is called right before w.Build().
+	BeforeBuild(w Widget)
+	// AfterBuild is called right after w.Build().
+	// At this point imgui's "last item" (e.g. imgui.ItemRectMin) refers to the last item submitted by w.
+	AfterBuild(w Widget)
+}
+
+// buildWidget builds w, applies CSS rules matching it and notifies build observers (if any).
+func buildWidget(w Widget) {
+	if Context == nil {
+		w.Build()
+		return
+	}
+
+	popCSS := applyCSS(w)
+	defer popCSS()
+
+	for _, o := range Context.buildObservers {
+		o.BeforeBuild(w)
+	}
+
+	w.Build()
+
+	for _, o := range Context.buildObservers {
+		o.AfterBuild(w)
+	}
+}
+
 // Splitable is implemented by widgets, which can be split (ranged)
 // Layout implements Splitable.
 type Splitable interface {
@@ -105,6 +105,10 @@ func (m *MarkdownWidget) Header(level int, font *FontInfo, fontSize float32, sep
 	// ensure level is in range
 	Assert(level < 3, "MarkdownWidget", "Header", "Header level must be less than 3!")
 
+	if level >= 3 {
+		return m
+	}
+
 	m.headers[level] = *immarkdown.NewEmptyMarkdownHeadingFormat()
 
 	if font != nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"image"
 	"image/color"
+	"time"
 
 	"github.com/AllenDang/cimgui-go/backend"
 	"github.com/AllenDang/cimgui-go/backend/glfwbackend"
@@ -65,6 +66,12 @@ type MasterWindow struct {
 // it should be called in main function. For more details and use cases,
 // see examples/helloworld/.
 func NewMasterWindow(title string, width, height int, flags MasterWindowFlags) *MasterWindow {
+	return NewMasterWindowWithBackend(NewGLFWBackend(), title, width, height, flags)
+}
+
+// NewMasterWindowWithBackend does the same as NewMasterWindow, but allows to use
+// a custom backend (e.g. HeadlessBackend for tests).
+func NewMasterWindowWithBackend(b GIUBackend, title string, width, height int, flags MasterWindowFlags) *MasterWindow {
 	imGuiContext := imgui.CreateContext()
 
 	implot.CreateContext()
@@ -76,7 +83,7 @@ func NewMasterWindow(title string, width, height int, flags MasterWindowFlags) *
 	// TODO: removed io.SetConfigFlags(imgui.BackendFlagsRendererHasVtxOffset)
 	io.SetBackendFlags(imgui.BackendFlagsRendererHasVtxOffset)
 
-	currentBackend, err := backend.CreateBackend(NewGLFWBackend())
+	currentBackend, err := backend.CreateBackend(b)
 	if err != nil && !errors.Is(err, backend.CExposerError) {
 		panic(err)
 	}
@@ -136,23 +143,48 @@ func (w *MasterWindow) beforeRender() {
 
 	Context.FontAtlas.rebuildFontAtlas()
 
+	// apply CSS stylesheet reloaded by WatchCSSStylesheet
+	if Context.cssWatcher != nil {
+		Context.cssWatcher.apply()
+	}
+
+	Context.profiler.textureLoads, Context.profiler.textureFrees = 0, 0
+
 	// process texture load requests
 	if Context.textureLoadingQueue != nil && Context.textureLoadingQueue.Length() > 0 {
+		Context.profiler.textureLoads = Context.textureLoadingQueue.Length()
+
 		for Context.textureLoadingQueue.Length() > 0 {
 			request, ok := Context.textureLoadingQueue.Remove().(textureLoadRequest)
 			Assert(ok, "MasterWindow", "Run", "processing texture requests: wrong type of texture request")
+
+			if !ok {
+				continue
+			}
+
 			NewTextureFromRgba(request.img, request.cb)
 		}
 	}
 
 	// process texture free requests
 	if Context.textureFreeingQueue != nil && Context.textureFreeingQueue.Length() > 0 {
+		Context.profiler.textureFrees = Context.textureFreeingQueue.Length()
+
 		for Context.textureFreeingQueue.Length() > 0 {
 			request, ok := Context.textureFreeingQueue.Remove().(textureFreeRequest)
 			Assert(ok, "MasterWindow", "Run", "processing texture requests: wrong type of texture request")
+
+			if !ok {
+				continue
+			}
+
 			request.tex.tex.Release()
+			Context.profiler.textures.Add(-1)
 		}
 	}
+
+	// process functions queued by Invoke
+	Context.runInvoked()
 }
 
 func (w *MasterWindow) afterRender() {
@@ -169,14 +201,25 @@ func (w *MasterWindow) render() {
 	Context.cleanStates()
 	defer Context.SetDirty()
 
+	Context.profiler.beginFrame(time.Now())
+	defer func() { Context.profiler.endFrame(time.Now()) }()
+
 	fin := w.setTheme()
 	defer fin()
 
 	mainStylesheet := Context.cssStylesheet.GetTag(MainTag)
+	Context.cssPath = Context.cssPath[:0]
+	clear(Context.preparedStrings)
 
 	mainStylesheet.Push()
 	w.updateFunc()
 	mainStylesheet.Pop()
+
+	Context.shortcutScopes.endFrame()
+
+	if Context.cssWatcher != nil {
+		Context.cssWatcher.buildErrorOverlay()
+	}
 }
 
 // Run runs the main loop.
@@ -241,6 +284,7 @@ func (w *MasterWindow) SetBgColor(bgColor color.Color) {
 // Default for GLFW is 30.
 func (w *MasterWindow) SetTargetFPS(fps uint) {
 	w.ctx.backend.SetTargetFPS(fps)
+	w.ctx.targetFPS = fps
 }
 
 // GetPos return position of master window.
@@ -306,6 +350,14 @@ func (w *MasterWindow) RegisterKeyboardShortcuts(s ...WindowShortcut) *MasterWin
 	return w
 }
 
+// RegisterNamedShortcuts registers a global - master window - keyboard shortcuts named by the map keys,
+// so their keys may be changed by a Keymap (see KeyBindingHandler.RegisterNamedShortcuts).
+func (w *MasterWindow) RegisterNamedShortcuts(s map[string]WindowShortcut) *MasterWindow {
+	registerNamedShortcuts(s, GlobalShortcut)
+
+	return w
+}
+
 // SetIcon sets the icon of the specified window. If passed an array of candidate images,
 // those of or closest to the sizes desired by the system are selected. If no images are
 // specified, the window reverts to its default icon.
@@ -369,6 +421,7 @@ func (w *MasterWindow) SetAdditionalInputHandlerCallback(cb InputHandlerHandleCa
 }
 
 // SetUserFile sets the path to the .ini file which saves the user preferences (e.g. subwindow positions, table column widths, etc.).
+// It also saves the arrangement of docked windows (see DockSpace).
 // Provide an empty string to disable it. This is the default.
 // See examples/sortable-table.
 func (w *MasterWindow) SetUserFile(path string) {
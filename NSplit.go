@@ -0,0 +1,462 @@
+package giu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+var _ Widget = &SplitPaneWidget{}
+
+// SplitPaneWidget wraps a widget to set its properties as a pane of NSplit.
+// Widgets passed to NSplit directly behave like SplitPane(widget).
+type SplitPaneWidget struct {
+	widget         Widget
+	size           float32
+	min, max       float32
+	notCollapsible bool
+}
+
+// SplitPane creates a new SplitPaneWidget.
+func SplitPane(widget Widget) *SplitPaneWidget {
+	return &SplitPaneWidget{
+		widget: widget,
+	}
+}
+
+// Size sets the initial size of the pane (in pixels or in percents - see NSplitWidget.SplitRefType).
+// Panes without size share the space left by other panes equally.
+func (p *SplitPaneWidget) Size(size float32) *SplitPaneWidget {
+	p.size = size
+	return p
+}
+
+// Min sets the minimum size of the pane in pixels.
+func (p *SplitPaneWidget) Min(minSize float32) *SplitPaneWidget {
+	p.min = minSize
+	return p
+}
+
+// Max sets the maximum size of the pane in pixels (0 means no limit).
+func (p *SplitPaneWidget) Max(maxSize float32) *SplitPaneWidget {
+	p.max = maxSize
+	return p
+}
+
+// Collapsible sets whether the pane can be collapsed by double-clicking a sash next to it (default true).
+func (p *SplitPaneWidget) Collapsible(collapsible bool) *SplitPaneWidget {
+	p.notCollapsible = !collapsible
+	return p
+}
+
+// Build implements Widget interface (it builds the widget only).
+func (p *SplitPaneWidget) Build() {
+	if p.widget != nil {
+		Layout{p.widget}.Build()
+	}
+}
+
+// nsplitLimits are constraints of a pane size.
+type nsplitLimits struct {
+	min, max    float32
+	collapsible bool
+}
+
+func (l nsplitLimits) clamp(size float32) float32 {
+	size = max(size, l.min)
+	if l.max > 0 {
+		size = min(size, l.max)
+	}
+
+	return size
+}
+
+var _ Disposable = &nsplitState{}
+
+type nsplitState struct {
+	// sizes of panes in pixels (0 for collapsed panes)
+	sizes []float32
+	// restore are sizes of collapsed panes from before they were collapsed
+	restore   []float32
+	collapsed []bool
+	// sashes keep deltas of sashes dragged by user in the last frame
+	sashes []splitLayoutState
+}
+
+// Dispose implements Disposable interface.
+func (s *nsplitState) Dispose() {
+	// noop
+}
+
+// fit makes sizes of visible panes sum up to total. The difference is taken from (or given to) the last panes first.
+func (s *nsplitState) fit(total float32, limits []nsplitLimits) {
+	var sum float32
+
+	for i := range s.sizes {
+		if s.collapsed[i] {
+			s.sizes[i] = 0
+			continue
+		}
+
+		s.sizes[i] = limits[i].clamp(s.sizes[i])
+		sum += s.sizes[i]
+	}
+
+	diff := total - sum
+
+	for i := len(s.sizes) - 1; i >= 0 && diff != 0; i-- {
+		if s.collapsed[i] {
+			continue
+		}
+
+		size := limits[i].clamp(s.sizes[i] + diff)
+		diff -= size - s.sizes[i]
+		s.sizes[i] = size
+	}
+}
+
+// drag moves sash (between panes sash and sash+1) by delta.
+func (s *nsplitState) drag(limits []nsplitLimits, sash int, delta float32) {
+	a, b := sash, sash+1
+	if s.collapsed[a] || s.collapsed[b] {
+		return
+	}
+
+	// delta is limited by the minimum and maximum sizes of both panes
+	lowest := limits[a].min - s.sizes[a]
+	if limits[b].max > 0 {
+		lowest = max(lowest, s.sizes[b]-limits[b].max)
+	}
+
+	highest := s.sizes[b] - limits[b].min
+	if limits[a].max > 0 {
+		highest = min(highest, limits[a].max-s.sizes[a])
+	}
+
+	if lowest > highest {
+		return
+	}
+
+	delta = min(max(delta, lowest), highest)
+	s.sizes[a] += delta
+	s.sizes[b] -= delta
+}
+
+// toggle expands a collapsed pane next to sash (the previous one is preferred)
+// or collapses a collapsible one if none is collapsed. The space is given to (or taken from) the other pane.
+func (s *nsplitState) toggle(limits []nsplitLimits, sash int) {
+	panes := [2]int{sash, sash + 1}
+
+	for _, i := range panes {
+		if !s.collapsed[i] {
+			continue
+		}
+
+		other := 2*sash + 1 - i
+		size := s.restore[i]
+
+		if !s.collapsed[other] {
+			size = min(size, max(s.sizes[other]-limits[other].min, 0))
+			s.sizes[other] -= size
+		}
+
+		s.sizes[i] = size
+		s.collapsed[i] = false
+
+		return
+	}
+
+	for _, i := range panes {
+		if !limits[i].collapsible {
+			continue
+		}
+
+		other := 2*sash + 1 - i
+		s.restore[i] = s.sizes[i]
+		s.sizes[other] += s.sizes[i]
+		s.sizes[i] = 0
+		s.collapsed[i] = true
+
+		return
+	}
+}
+
+// splitPositions are saved positions of sashes of NSplit (see SaveSplitPositions).
+type splitPositions struct {
+	// Sizes are parts of the total size of panes (0-1). Sizes of collapsed panes are from before they were collapsed.
+	Sizes     []float32 `json:"sizes"`
+	Collapsed []bool    `json:"collapsed,omitempty"`
+}
+
+var _ Widget = &NSplitWidget{}
+
+// NSplitWidget splits available space into any number of panes with sashes between them.
+// Sashes can be dragged by user (in range set by SplitPaneWidget.Min and Max)
+// and double-clicking a sash collapses (or expands) a pane next to it.
+// NSplit may be nested in a pane of another NSplit (or SplitLayout).
+//
+// Positions of sashes are stored by ID, so they may be saved and restored across sessions
+// (see SaveSplitPositions and LoadSplitPositions).
+type NSplitWidget struct {
+	id           ID
+	direction    SplitDirection
+	panes        []Widget
+	border       bool
+	splitRefType SplitRefType
+}
+
+// NSplit creates a new NSplitWidget. direction is the direction of sashes (like in SplitLayout),
+// so panes of DirectionVertical are placed side by side.
+func NSplit(direction SplitDirection, panes ...Widget) *NSplitWidget {
+	return &NSplitWidget{
+		id:        GenAutoID("NSplit"),
+		direction: direction,
+		panes:     panes,
+		border:    true,
+	}
+}
+
+// ID sets the id of the split. It is used to save and restore positions of sashes,
+// so it should be set if the layout (or order of widgets) may change between sessions.
+func (s *NSplitWidget) ID(id ID) *NSplitWidget {
+	s.id = id
+	return s
+}
+
+// Border sets if panes should have borders.
+func (s *NSplitWidget) Border(b bool) *NSplitWidget {
+	s.border = b
+	return s
+}
+
+// SplitRefType sets how sizes of panes (see SplitPaneWidget.Size) are interpreted:
+// SplitRefProc means parts of the available space (0-1), any other value means pixels.
+func (s *NSplitWidget) SplitRefType(refType SplitRefType) *NSplitWidget {
+	s.splitRefType = refType
+	return s
+}
+
+// Panes sets panes of the split.
+func (s *NSplitWidget) Panes(panes ...Widget) *NSplitWidget {
+	s.panes = panes
+	return s
+}
+
+// getState returns the state; it is created (or restored from saved positions) if the number of panes changes.
+func (s *NSplitWidget) getState(panes []*SplitPaneWidget, total float32) *nsplitState {
+	state := GetState[nsplitState](Context, s.id)
+	if state != nil && len(state.sizes) == len(panes) {
+		return state
+	}
+
+	state = &nsplitState{
+		sizes:     make([]float32, len(panes)),
+		restore:   make([]float32, len(panes)),
+		collapsed: make([]bool, len(panes)),
+		sashes:    make([]splitLayoutState, len(panes)-1),
+	}
+
+	if saved, ok := Context.splitPositions[s.id]; ok && len(saved.Sizes) == len(panes) {
+		for i, size := range saved.Sizes {
+			state.sizes[i] = size * total
+
+			if i < len(saved.Collapsed) && saved.Collapsed[i] {
+				state.restore[i], state.sizes[i] = state.sizes[i], 0
+				state.collapsed[i] = true
+			}
+		}
+	} else {
+		remaining, unset := total, 0
+
+		for i, pane := range panes {
+			switch {
+			case pane.size <= 0:
+				unset++
+				continue
+			case s.splitRefType == SplitRefProc:
+				state.sizes[i] = min(pane.size, 1) * total
+			default:
+				state.sizes[i] = pane.size
+			}
+
+			remaining -= state.sizes[i]
+		}
+
+		for i, pane := range panes {
+			if pane.size <= 0 {
+				state.sizes[i] = max(remaining/float32(unset), 0)
+			}
+		}
+	}
+
+	SetState(Context, s.id, state)
+
+	return state
+}
+
+// Build implements Widget interface.
+func (s *NSplitWidget) Build() {
+	if len(s.panes) == 0 {
+		return
+	}
+
+	panes := make([]*SplitPaneWidget, len(s.panes))
+	limits := make([]nsplitLimits, len(s.panes))
+
+	for i, w := range s.panes {
+		pane, ok := w.(*SplitPaneWidget)
+		if !ok {
+			pane = SplitPane(w)
+		}
+
+		panes[i] = pane
+		// NOTE: child of size 0 would fill all available space
+		limits[i] = nsplitLimits{min: max(pane.min, 1), max: pane.max, collapsible: !pane.notCollapsible}
+	}
+
+	itemSpacingX, itemSpacingY := GetItemInnerSpacing()
+	framePaddingX, framePaddingY := GetFramePadding()
+	availableW, availableH := GetAvailableRegion()
+
+	thickness, available := itemSpacingX, availableW
+	if s.direction == DirectionHorizontal {
+		thickness, available = itemSpacingY, availableH
+	}
+
+	total := max(available-thickness*float32(len(panes)-1), 0)
+	state := s.getState(panes, total)
+
+	// sashes dragged in the last frame
+	for i := range state.sashes {
+		if delta := state.sashes[i].delta; delta != 0 {
+			state.drag(limits, i, delta)
+		}
+	}
+
+	state.fit(total, limits)
+
+	itemSpacing := imgui.Vec2{X: itemSpacingX, Y: itemSpacingY}
+	framePadding := imgui.Vec2{X: framePaddingX, Y: framePaddingY}
+
+	var layout Layout
+
+	for i, pane := range panes {
+		if i > 0 {
+			layout = append(layout, s.buildSash(state, limits, i-1, thickness))
+		}
+
+		if state.collapsed[i] {
+			continue
+		}
+
+		width, height := state.sizes[i], Auto
+		if s.direction == DirectionHorizontal {
+			width, height = Auto, state.sizes[i]
+		}
+
+		layout = append(layout, splitChild(width, height, s.border, itemSpacing, framePadding, pane))
+	}
+
+	PushItemSpacing(0, 0)
+
+	if s.direction == DirectionHorizontal {
+		Column(layout...).Build()
+	} else {
+		Row(layout...).Build()
+	}
+
+	PopStyle()
+
+	s.savePositions(state, total)
+}
+
+// buildSash creates a sash between panes sash and sash+1.
+func (s *NSplitWidget) buildSash(state *nsplitState, limits []nsplitLimits, sash int, thickness float32) Widget {
+	return Custom(func() {
+		splitter := Splitter(s.direction, &state.sashes[sash].delta).
+			ID(ID(fmt.Sprintf("%s_sash%d", s.id, sash)))
+
+		if s.direction == DirectionHorizontal {
+			splitter.Size(0, thickness)
+		} else {
+			splitter.Size(thickness, 0)
+		}
+
+		splitter.Build()
+
+		if IsItemHovered() && IsMouseDoubleClicked(MouseButtonLeft) {
+			state.toggle(limits, sash)
+		}
+	})
+}
+
+func (s *NSplitWidget) savePositions(state *nsplitState, total float32) {
+	if total <= 0 {
+		return
+	}
+
+	saved, ok := Context.splitPositions[s.id]
+	if !ok || len(saved.Sizes) != len(state.sizes) {
+		saved = &splitPositions{
+			Sizes:     make([]float32, len(state.sizes)),
+			Collapsed: make([]bool, len(state.sizes)),
+		}
+		Context.splitPositions[s.id] = saved
+	}
+
+	for i, size := range state.sizes {
+		if state.collapsed[i] {
+			size = state.restore[i]
+		}
+
+		saved.Sizes[i] = size / total
+	}
+
+	copy(saved.Collapsed, state.collapsed)
+}
+
+// SaveSplitPositions saves positions of sashes of all NSplit widgets built so far
+// (and positions loaded by LoadSplitPositions) to a JSON file at path.
+// It may be called e.g. in MasterWindow's close callback (see MasterWindow.SetCloseCallback).
+func SaveSplitPositions(path string) error {
+	data, err := json.MarshalIndent(Context.splitPositions, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encoding split positions: %w", err)
+	}
+
+	const newFileMode = 0o644
+	if err := os.WriteFile(path, data, newFileMode); err != nil {
+		return fmt.Errorf("saving split positions: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSplitPositions loads positions of sashes saved by SaveSplitPositions.
+// They are applied to NSplit widgets (matched by ID) when they are built for the first time.
+// It is noop if the file doesn't exist.
+func LoadSplitPositions(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("loading split positions: %w", err)
+	}
+
+	positions := make(map[ID]*splitPositions)
+	if err := json.Unmarshal(data, &positions); err != nil {
+		return fmt.Errorf("decoding split positions from %s: %w", path, err)
+	}
+
+	for id, p := range positions {
+		Context.splitPositions[id] = p
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package giu_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_NSplit(t *testing.T) {
+	// buttons of width -1 fill their panes except of the last pixel
+	pane := func(label string) giu.Widget {
+		return giu.Button(label).Size(-1, 20)
+	}
+
+	h := giutest.New(t, 400, 300)
+	h.Run(3, func() giu.Layout {
+		return giu.Layout{
+			giu.NSplit(giu.DirectionVertical,
+				giu.SplitPane(pane("left")).Size(100).Min(50),
+				pane("mid"),
+				giu.SplitPane(pane("right")).Size(80).Max(120),
+			).ID("main").Border(false),
+		}
+	})
+
+	width := func(label string) int {
+		return h.MustFind(label).Rect.Dx()
+	}
+
+	left, mid, right := width("left"), width("mid"), width("right")
+	assert.Equal(t, 100-1, left, "unexpected initial size")
+	assert.Equal(t, 80-1, right, "unexpected initial size")
+
+	// sashes are right after the panes
+	sash := func(label string) image.Point {
+		item := h.MustFind(label)
+		return image.Pt(item.Rect.Max.X+2, item.Center().Y)
+	}
+
+	from := sash("left")
+	h.Drag(from, from.Add(image.Pt(30, 0)))
+	assert.Equal(t, left+30, width("left"), "sash not dragged")
+	assert.Equal(t, mid-30, width("mid"), "space not taken from the next pane")
+	assert.Equal(t, right, width("right"), "other pane resized")
+
+	from = sash("left")
+	h.Drag(from, from.Add(image.Pt(-200, 0)))
+	assert.Equal(t, 50-1, width("left"), "minimum size not respected")
+	assert.Equal(t, mid+50, width("mid"), "space not given to the next pane")
+
+	from = sash("mid")
+	h.Drag(from, from.Add(image.Pt(-100, 0)))
+	assert.Equal(t, 120-1, width("right"), "maximum size not respected")
+	assert.Equal(t, mid+50-40, width("mid"), "space not taken from the previous pane")
+}
+
+func Test_Harness_NSplitInSplitPane(t *testing.T) {
+	h := giutest.New(t, 400, 300)
+	h.Run(3, func() giu.Layout {
+		return giu.Layout{
+			giu.Button("origin").Size(1, 1),
+			giu.NSplit(giu.DirectionVertical,
+				giu.SplitPane(giu.NSplit(giu.DirectionHorizontal,
+					giu.Button("top"),
+					giu.Button("bottom"),
+				).Border(false)).Size(100),
+				giu.Button("right"),
+			),
+		}
+	})
+
+	assert.Equal(t, h.MustFind("origin").Rect.Min.X, h.MustFind("top").Rect.Min.X, "nested split wrapped in SplitPane has padding")
+	assert.Greater(t, h.MustFind("right").Rect.Min.X, h.MustFind("origin").Rect.Min.X+100, "pane not padded")
+}
@@ -0,0 +1,84 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNSplitState(sizes ...float32) *nsplitState {
+	return &nsplitState{
+		sizes:     sizes,
+		restore:   make([]float32, len(sizes)),
+		collapsed: make([]bool, len(sizes)),
+		sashes:    make([]splitLayoutState, len(sizes)-1),
+	}
+}
+
+func Test_NSplitFit(t *testing.T) {
+	tests := []struct {
+		name     string
+		sizes    []float32
+		limits   []nsplitLimits
+		total    float32
+		expected []float32
+	}{
+		{"grow last", []float32{100, 100, 100}, []nsplitLimits{{}, {}, {}}, 400, []float32{100, 100, 200}},
+		{"shrink last", []float32{100, 100, 100}, []nsplitLimits{{}, {}, {}}, 250, []float32{100, 100, 50}},
+		{"last at minimum", []float32{100, 100, 100}, []nsplitLimits{{}, {}, {min: 80}}, 250, []float32{100, 70, 80}},
+		{"last at maximum", []float32{100, 100, 100}, []nsplitLimits{{}, {}, {max: 120}}, 400, []float32{100, 180, 120}},
+		{"clamp", []float32{10, 100, 100}, []nsplitLimits{{min: 50}, {}, {}}, 250, []float32{50, 100, 100}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state := newTestNSplitState(tc.sizes...)
+			state.fit(tc.total, tc.limits)
+			assert.Equal(t, tc.expected, state.sizes, "unexpected sizes")
+		})
+	}
+}
+
+func Test_NSplitDrag(t *testing.T) {
+	limits := []nsplitLimits{{min: 50}, {min: 20, max: 150}, {}}
+
+	state := newTestNSplitState(100, 100, 100)
+	state.drag(limits, 0, 30)
+	assert.Equal(t, []float32{130, 70, 100}, state.sizes, "sash not moved")
+
+	state.drag(limits, 0, 100)
+	assert.Equal(t, []float32{180, 20, 100}, state.sizes, "minimum of the next pane not respected")
+
+	state.drag(limits, 0, -200)
+	assert.Equal(t, []float32{50, 150, 100}, state.sizes, "minimum/maximum not respected")
+
+	state.drag(limits, 1, 10)
+	assert.Equal(t, []float32{50, 150, 100}, state.sizes, "maximum of the previous pane not respected")
+}
+
+func Test_NSplitToggle(t *testing.T) {
+	limits := []nsplitLimits{{collapsible: true}, {min: 20, collapsible: true}, {}}
+
+	state := newTestNSplitState(100, 100, 100)
+	state.toggle(limits, 0)
+	assert.Equal(t, []float32{0, 200, 100}, state.sizes, "pane before the sash not collapsed")
+	assert.Equal(t, []bool{true, false, false}, state.collapsed)
+
+	state.drag(limits, 0, 50)
+	assert.Equal(t, []float32{0, 200, 100}, state.sizes, "collapsed pane resized")
+
+	state.toggle(limits, 0)
+	assert.Equal(t, []float32{100, 100, 100}, state.sizes, "pane not expanded")
+	assert.Equal(t, []bool{false, false, false}, state.collapsed)
+
+	// the last pane isn't collapsible, so the previous one is collapsed
+	state.toggle(limits, 1)
+	assert.Equal(t, []float32{100, 0, 200}, state.sizes, "collapsible pane not collapsed")
+
+	state.toggle(limits, 1)
+	assert.Equal(t, []float32{100, 100, 100}, state.sizes, "pane not expanded")
+
+	// not collapsible panes
+	state.toggle([]nsplitLimits{{}, {}, {}}, 1)
+	assert.Equal(t, []bool{false, false, false}, state.collapsed, "not collapsible pane collapsed")
+}
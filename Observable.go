@@ -0,0 +1,173 @@
+package giu
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Dependency is anything which notifies about its changes (e.g. Observable).
+// See Computed.
+type Dependency interface {
+	// OnChange registers f called after each change. It returns a function removing the registration.
+	OnChange(f func()) (unsubscribe func())
+}
+
+// Signal is a read-only value which notifies about its changes.
+// It is implemented by Observable and returned by Computed and Map.
+type Signal[T any] interface {
+	Dependency
+	// Get returns the current value.
+	Get() T
+	// Subscribe registers f called with the new value after each change.
+	// It returns a function removing the subscription.
+	Subscribe(f func(value T)) (unsubscribe func())
+}
+
+var _ Signal[int] = &Observable[int]{}
+
+// Observable is a value which may be read and written from any goroutine.
+// Each write requests a new frame (see Update), so UI always shows the current value,
+// and calls subscribers (in the writing goroutine).
+//
+// Widgets can be bound to observables (e.g. InputTextBound, CheckboxBound).
+type Observable[T any] struct {
+	m           *sync.RWMutex
+	value       T
+	subscribers map[int]func(T)
+	nextID      int
+}
+
+// NewObservable creates a new Observable with initial value.
+func NewObservable[T any](value T) *Observable[T] {
+	return &Observable[T]{
+		m:           &sync.RWMutex{},
+		value:       value,
+		subscribers: make(map[int]func(T)),
+	}
+}
+
+// Get returns the current value.
+func (o *Observable[T]) Get() T {
+	o.m.RLock()
+	defer o.m.RUnlock()
+
+	return o.value
+}
+
+// Set changes the value, requests a new frame and calls subscribers.
+func (o *Observable[T]) Set(value T) {
+	o.Modify(func(T) T {
+		return value
+	})
+}
+
+// Modify sets the value to f(current value). The value is locked while f runs,
+// so concurrent Modify calls are not lost (unlike Get followed by Set).
+func (o *Observable[T]) Modify(f func(value T) T) {
+	o.m.Lock()
+	o.value = f(o.value)
+	value := o.value
+
+	subscribers := make([]func(T), 0, len(o.subscribers))
+	for _, id := range slices.Sorted(maps.Keys(o.subscribers)) {
+		subscribers = append(subscribers, o.subscribers[id])
+	}
+
+	o.m.Unlock()
+
+	if Context != nil {
+		Update()
+	}
+
+	for _, f := range subscribers {
+		f(value)
+	}
+}
+
+// Subscribe implements Signal interface.
+// Subscribers are called in order of registration.
+func (o *Observable[T]) Subscribe(f func(value T)) (unsubscribe func()) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	id := o.nextID
+	o.nextID++
+	o.subscribers[id] = f
+
+	return func() {
+		o.m.Lock()
+		defer o.m.Unlock()
+
+		delete(o.subscribers, id)
+	}
+}
+
+// OnChange implements Dependency interface.
+func (o *Observable[T]) OnChange(f func()) (unsubscribe func()) {
+	return o.Subscribe(func(T) { f() })
+}
+
+// Computed returns a Signal which value is compute() recalculated whenever any of deps changes.
+// NOTE: it stays subscribed to deps as long as they exist.
+func Computed[T any](compute func() T, deps ...Dependency) Signal[T] {
+	result := NewObservable(compute())
+
+	for _, dep := range deps {
+		dep.OnChange(func() {
+			result.Set(compute())
+		})
+	}
+
+	return result
+}
+
+// Map returns a Signal which value is f(source.Get()) (see Computed).
+func Map[T, U any](source Signal[T], f func(value T) U) Signal[U] {
+	return Computed(func() U {
+		return f(source.Get())
+	}, source)
+}
+
+// InputTextBound creates InputTextWidget which edits value of o.
+func InputTextBound(o *Observable[string]) *InputTextWidget {
+	value := o.Get()
+	result := InputText(&value)
+	result.bind = func() { o.Set(value) }
+
+	return result
+}
+
+// SliderFloatBound creates SliderFloatWidget which edits value of o.
+func SliderFloatBound(o *Observable[float32], minValue, maxValue float32) *SliderFloatWidget {
+	value := o.Get()
+	result := SliderFloat(&value, minValue, maxValue)
+	result.bind = func() { o.Set(value) }
+
+	return result
+}
+
+// CheckboxBound creates CheckboxWidget which edits value of o.
+func CheckboxBound(text string, o *Observable[bool]) *CheckboxWidget {
+	value := o.Get()
+	result := Checkbox(text, &value)
+	result.bind = func() { o.Set(value) }
+
+	return result
+}
+
+// ComboBound creates ComboWidget which selects an item of items; index of the selected item is stored in o.
+// Preview value is the selected item (or empty if o is out of range).
+func ComboBound(label string, items []string, o *Observable[int32]) *ComboWidget {
+	value := o.Get()
+
+	var preview string
+	if value >= 0 && int(value) < len(items) {
+		preview = items[value]
+	}
+
+	result := Combo(label, preview, items, &value)
+	result.bind = func() { o.Set(value) }
+
+	return result
+}
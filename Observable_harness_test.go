@@ -0,0 +1,27 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_Bound(t *testing.T) {
+	enabled := giu.NewObservable(false)
+	changed := 0
+
+	h := giutest.New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{
+			giu.CheckboxBound("Enabled", enabled).OnChange(func() { changed++ }),
+		}
+	})
+
+	h.Click("Enabled")
+
+	assert.True(t, enabled.Get(), "observable not updated")
+	assert.Equal(t, 1, changed, "OnChange not called for bound widget")
+}
@@ -0,0 +1,59 @@
+package giu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservable(t *testing.T) {
+	o := NewObservable(1)
+
+	var received []int
+
+	unsubscribe := o.Subscribe(func(v int) {
+		received = append(received, v)
+	})
+
+	o.Set(2)
+	o.Modify(func(v int) int { return v * 10 })
+	unsubscribe()
+	o.Set(3)
+
+	assert.Equal(t, 3, o.Get(), "unexpected value")
+	assert.Equal(t, []int{2, 20}, received, "unexpected notifications")
+}
+
+func TestObservable_Modify_concurrent(t *testing.T) {
+	o := NewObservable(0)
+
+	var wg sync.WaitGroup
+
+	for range 100 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			o.Modify(func(v int) int { return v + 1 })
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, o.Get(), "lost updates")
+}
+
+func TestComputed(t *testing.T) {
+	first, last := NewObservable("John"), NewObservable("Smith")
+	full := Computed(func() string { return first.Get() + " " + last.Get() }, first, last)
+	length := Map(full, func(s string) int { return len(s) })
+
+	assert.Equal(t, "John Smith", full.Get(), "unexpected initial value")
+
+	last.Set("Doe")
+
+	assert.Equal(t, "John Doe", full.Get(), "value not recomputed")
+	assert.Equal(t, 8, length.Get(), "derived value not recomputed")
+}
@@ -0,0 +1,304 @@
+package giu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PluralCategory is a CLDR plural category (see https://cldr.unicode.org/index/cldr-spec/plural-rules).
+type PluralCategory string
+
+// Plural categories in the CLDR order.
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// ParsePluralCategory returns category named s (e.g. "few").
+func ParsePluralCategory(s string) (PluralCategory, error) {
+	switch c := PluralCategory(s); c {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown plural category %q", s)
+	}
+}
+
+// pluralRule describes plural rules of a language (for integers).
+type pluralRule struct {
+	// categories used by the language for integers in the CLDR order
+	// (it is also the usual order of gettext's msgstr[n] forms)
+	categories []PluralCategory
+	category   func(n int) PluralCategory
+}
+
+func inRange(n, from, to int) bool {
+	return n >= from && n <= to
+}
+
+var (
+	pluralRuleOther = &pluralRule{
+		categories: []PluralCategory{PluralOther},
+		category:   func(int) PluralCategory { return PluralOther },
+	}
+
+	pluralRuleOne = &pluralRule{
+		categories: []PluralCategory{PluralOne, PluralOther},
+		category: func(n int) PluralCategory {
+			if n == 1 {
+				return PluralOne
+			}
+
+			return PluralOther
+		},
+	}
+
+	pluralRuleZeroOne = &pluralRule{
+		categories: []PluralCategory{PluralOne, PluralOther},
+		category: func(n int) PluralCategory {
+			if n == 0 || n == 1 {
+				return PluralOne
+			}
+
+			return PluralOther
+		},
+	}
+
+	// e.g. ru, uk
+	pluralRuleEastSlavic = &pluralRule{
+		categories: []PluralCategory{PluralOne, PluralFew, PluralMany},
+		category: func(n int) PluralCategory {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		},
+	}
+
+	// e.g. hr, sr
+	pluralRuleSouthSlavic = &pluralRule{
+		categories: []PluralCategory{PluralOne, PluralFew, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		},
+	}
+
+	// e.g. cs, sk
+	pluralRuleWestSlavic = &pluralRule{
+		categories: []PluralCategory{PluralOne, PluralFew, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case inRange(n, 2, 4):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		},
+	}
+)
+
+// pluralRules maps languages (without region) to their plural rules.
+// Languages not listed here use the "other" category only.
+var pluralRules = map[string]*pluralRule{
+	"ja": pluralRuleOther, "zh": pluralRuleOther, "ko": pluralRuleOther, "vi": pluralRuleOther,
+	"th": pluralRuleOther, "id": pluralRuleOther, "ms": pluralRuleOther, "lo": pluralRuleOther,
+	"my": pluralRuleOther, "km": pluralRuleOther,
+
+	"en": pluralRuleOne, "de": pluralRuleOne, "nl": pluralRuleOne, "sv": pluralRuleOne,
+	"da": pluralRuleOne, "no": pluralRuleOne, "nb": pluralRuleOne, "nn": pluralRuleOne,
+	"fi": pluralRuleOne, "et": pluralRuleOne, "el": pluralRuleOne, "hu": pluralRuleOne,
+	"bg": pluralRuleOne, "tr": pluralRuleOne, "az": pluralRuleOne, "ka": pluralRuleOne,
+	"sq": pluralRuleOne, "eu": pluralRuleOne, "gl": pluralRuleOne, "af": pluralRuleOne,
+	"sw": pluralRuleOne, "ur": pluralRuleOne, "ta": pluralRuleOne, "te": pluralRuleOne,
+	"ml": pluralRuleOne, "kk": pluralRuleOne, "uz": pluralRuleOne, "mn": pluralRuleOne,
+	"it": pluralRuleOne, "es": pluralRuleOne, "ca": pluralRuleOne, "eo": pluralRuleOne,
+
+	"fr": pluralRuleZeroOne, "pt": pluralRuleZeroOne, "hi": pluralRuleZeroOne, "bn": pluralRuleZeroOne,
+	"fa": pluralRuleZeroOne, "gu": pluralRuleZeroOne, "kn": pluralRuleZeroOne, "mr": pluralRuleZeroOne,
+	"zu": pluralRuleZeroOne, "am": pluralRuleZeroOne, "hy": pluralRuleZeroOne,
+
+	"ru": pluralRuleEastSlavic, "uk": pluralRuleEastSlavic, "be": pluralRuleEastSlavic,
+	"hr": pluralRuleSouthSlavic, "sr": pluralRuleSouthSlavic, "bs": pluralRuleSouthSlavic,
+	"cs": pluralRuleWestSlavic, "sk": pluralRuleWestSlavic,
+
+	"pl": {
+		categories: []PluralCategory{PluralOne, PluralFew, PluralMany},
+		category: func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case inRange(n%10, 2, 4) && !inRange(n%100, 12, 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		},
+	},
+	"lt": {
+		categories: []PluralCategory{PluralOne, PluralFew, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case inRange(n%100, 11, 19):
+				return PluralOther
+			case n%10 == 1:
+				return PluralOne
+			case n%10 >= 2:
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"lv": {
+		categories: []PluralCategory{PluralZero, PluralOne, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n%10 == 0 || inRange(n%100, 11, 19):
+				return PluralZero
+			case n%10 == 1:
+				return PluralOne
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"ro": {
+		categories: []PluralCategory{PluralOne, PluralFew, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n == 0 || inRange(n%100, 2, 19):
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"sl": {
+		categories: []PluralCategory{PluralOne, PluralTwo, PluralFew, PluralOther},
+		category: func(n int) PluralCategory {
+			switch n % 100 {
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			case 3, 4:
+				return PluralFew
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"he": {
+		categories: []PluralCategory{PluralOne, PluralTwo, PluralOther},
+		category: func(n int) PluralCategory {
+			switch n {
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"ga": {
+		categories: []PluralCategory{PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n == 1:
+				return PluralOne
+			case n == 2:
+				return PluralTwo
+			case inRange(n, 3, 6):
+				return PluralFew
+			case inRange(n, 7, 10):
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"cy": {
+		categories: []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		category: func(n int) PluralCategory {
+			switch n {
+			case 0:
+				return PluralZero
+			case 1:
+				return PluralOne
+			case 2:
+				return PluralTwo
+			case 3:
+				return PluralFew
+			case 6:
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		},
+	},
+	"ar": {
+		categories: []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		category: func(n int) PluralCategory {
+			switch {
+			case n == 0:
+				return PluralZero
+			case n == 1:
+				return PluralOne
+			case n == 2:
+				return PluralTwo
+			case inRange(n%100, 3, 10):
+				return PluralFew
+			case inRange(n%100, 11, 99):
+				return PluralMany
+			default:
+				return PluralOther
+			}
+		},
+	},
+}
+
+func pluralRuleFor(tag string) *pluralRule {
+	language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	language, _, _ = strings.Cut(language, "_")
+
+	if rule, ok := pluralRules[language]; ok {
+		return rule
+	}
+
+	return pluralRuleOther
+}
+
+// PluralCategoryOf returns CLDR plural category of integer n in language tag (e.g. "pl" or "pt-BR").
+func PluralCategoryOf(tag string, n int) PluralCategory {
+	if n < 0 {
+		n = -n
+	}
+
+	return pluralRuleFor(tag).category(n)
+}
+
+// PluralCategories returns plural categories used by language tag for integers in the CLDR order.
+// Plural forms (msgstr[n]) of gettext catalogs are assumed to be in this order.
+func PluralCategories(tag string) []PluralCategory {
+	return pluralRuleFor(tag).categories
+}
@@ -0,0 +1,230 @@
+package giu
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// profilerFrames is the number of frames shown by ProfilerOverlay.
+const profilerFrames = 120
+
+// profiler collects timings of frames and of Profile widgets.
+type profiler struct {
+	frameStart time.Time
+	// frameTimes is a ring buffer of build times of the last frames (in ms); next is the index of the oldest one.
+	// giu redraws on demand, so time between frames (idle time included) says nothing about performance.
+	frameTimes []float64
+	next       int
+
+	// build times of Profile widgets in the current and in the last (complete) frame
+	current, last           map[string]time.Duration
+	currentOrder, lastOrder []string
+
+	// lengths of texture queues at the beginning of the frame (before they were processed)
+	textureLoads, textureFrees int
+	// textures is the number of textures created by NewTextureFromRgba (e.g. for EnqueueNewTextureFromRgba)
+	// which weren't freed by the texture freeing queue yet
+	textures atomic.Int64
+}
+
+func newProfiler() *profiler {
+	return &profiler{
+		current: make(map[string]time.Duration),
+		last:    make(map[string]time.Duration),
+	}
+}
+
+// beginFrame is called at the beginning of MasterWindow.render.
+func (p *profiler) beginFrame(now time.Time) {
+	p.frameStart = now
+
+	p.last, p.current = p.current, p.last
+	p.lastOrder, p.currentOrder = p.currentOrder, p.lastOrder[:0]
+
+	clear(p.current)
+}
+
+// endFrame is called at the end of MasterWindow.render. It records the build time of the frame.
+func (p *profiler) endFrame(now time.Time) {
+	p.addFrameTime(now.Sub(p.frameStart))
+}
+
+func (p *profiler) addFrameTime(d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+
+	if len(p.frameTimes) < profilerFrames {
+		p.frameTimes = append(p.frameTimes, ms)
+		return
+	}
+
+	p.frameTimes[p.next] = ms
+	p.next = (p.next + 1) % profilerFrames
+}
+
+// frames returns frame times (in ms) from the oldest one.
+func (p *profiler) frames() []float64 {
+	return append(append([]float64(nil), p.frameTimes[p.next:]...), p.frameTimes[:p.next]...)
+}
+
+// averageFrameTime returns average build time of the recorded frames (in ms).
+func (p *profiler) averageFrameTime() float64 {
+	if len(p.frameTimes) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, ms := range p.frameTimes {
+		total += ms
+	}
+
+	return total / float64(len(p.frameTimes))
+}
+
+// record adds d to the build time of name in the current frame.
+func (p *profiler) record(name string, d time.Duration) {
+	if _, ok := p.current[name]; !ok {
+		p.currentOrder = append(p.currentOrder, name)
+	}
+
+	p.current[name] += d
+}
+
+var _ Widget = &ProfileWidget{}
+
+// ProfileWidget measures time of building its widgets. The time is shown by ProfilerOverlay.
+type ProfileWidget struct {
+	name   string
+	layout Layout
+}
+
+// Profile creates a new ProfileWidget. Times of widgets with the same name are summed, e.g.:
+//
+//	giu.Window("Dashboard").Layout(
+//		giu.Profile("Dashboard", widgets...),
+//	)
+//
+// NOTE: it measures building of the widgets (e.g. preparing draw commands by imgui), not rendering.
+func Profile(name string, widgets ...Widget) *ProfileWidget {
+	return &ProfileWidget{
+		name:   name,
+		layout: widgets,
+	}
+}
+
+// Build implements Widget interface.
+func (p *ProfileWidget) Build() {
+	start := time.Now()
+
+	p.layout.Build()
+
+	Context.profiler.record(p.name, time.Since(start))
+}
+
+var _ Widget = &ProfilerOverlayWidget{}
+
+// ProfilerOverlayWidget is a window showing build times of frames and of Profile widgets, FPS,
+// the number of states in Context and of textures loaded by NewTextureFromRgba.
+// Frames are built only when needed (e.g. on user input), so the time between them isn't shown.
+type ProfilerOverlayWidget struct {
+	open *bool
+	x, y float32
+}
+
+// ProfilerOverlay creates a new ProfilerOverlayWidget. It is a window, so build it in the loop function:
+//
+//	giu.ProfilerOverlay().Build()
+func ProfilerOverlay() *ProfilerOverlayWidget {
+	return &ProfilerOverlayWidget{
+		x: 10,
+		y: 10,
+	}
+}
+
+// IsOpen sets a pointer to the visibility of the overlay (it adds close button to it).
+func (p *ProfilerOverlayWidget) IsOpen(open *bool) *ProfilerOverlayWidget {
+	p.open = open
+	return p
+}
+
+// Pos sets the initial position of the overlay.
+func (p *ProfilerOverlayWidget) Pos(x, y float32) *ProfilerOverlayWidget {
+	p.x, p.y = x, y
+	return p
+}
+
+// Build implements Widget interface.
+func (p *ProfilerOverlayWidget) Build() {
+	if p.open != nil && !*p.open {
+		return
+	}
+
+	prof := Context.profiler
+	frames := prof.frames()
+
+	var maxFrame float64
+	for _, ms := range frames {
+		maxFrame = max(maxFrame, ms)
+	}
+
+	summary := fmt.Sprintf("Frame build time: %.3f ms", prof.averageFrameTime())
+
+	// NOTE: lines can't be plotted without values
+	var plots []PlotWidget
+	if len(frames) > 0 {
+		plots = append(plots, Line("build time (ms)", frames))
+	}
+
+	if Context.targetFPS > 0 {
+		budget := 1000 / float64(Context.targetFPS)
+		summary += fmt.Sprintf(" (budget: %.1f ms)", budget)
+		maxFrame = max(maxFrame, budget)
+
+		if len(frames) > 0 {
+			budgetLine := make([]float64, len(frames))
+			for i := range budgetLine {
+				budgetLine[i] = budget
+			}
+
+			plots = append(plots, Line("budget (ms)", budgetLine))
+		}
+	}
+
+	fps := fmt.Sprintf("FPS: %.1f", imgui.CurrentIO().Framerate())
+	if Context.targetFPS > 0 {
+		fps += fmt.Sprintf(" (target: %d)", Context.targetFPS)
+	}
+
+	timings := Layout{}
+	for _, name := range prof.lastOrder {
+		timings = append(timings, Labelf("%s: %.3f ms", name, float64(prof.last[name])/float64(time.Millisecond)))
+	}
+
+	if len(timings) == 0 {
+		timings = append(timings, Label("Wrap widgets in giu.Profile to measure them."))
+	}
+
+	Window("Profiler##giuProfiler").
+		IsOpen(p.open).
+		Pos(p.x, p.y).
+		Flags(WindowFlagsAlwaysAutoResize|WindowFlagsNoSavedSettings|WindowFlagsNoFocusOnAppearing).
+		Layout(
+			Label(summary),
+			Label(fps),
+			Plot("##giuProfilerFrames").
+				Flags(PlotFlagsNoTitle|PlotFlagsNoMenus|PlotFlagsNoBoxSelect).
+				XLim(0, profilerFrames, ConditionAlways).
+				YLim(0, maxFrame*1.2, ConditionAlways).
+				Size(300, 120).
+				Plots(plots...),
+			Separator(),
+			Label("Build times (last frame):"),
+			timings,
+			Separator(),
+			Labelf("States: %d", Context.stateCount()),
+			Labelf("Textures: %d", prof.textures.Load()),
+			Labelf("Texture requests: %d to load, %d to free", prof.textureLoads, prof.textureFrees),
+		)
+}
@@ -0,0 +1,50 @@
+package giu_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_Profile(t *testing.T) {
+	h := giutest.New(t, 400, 300)
+	h.Run(3, func() giu.Layout {
+		return giu.Layout{
+			giu.Profile("toolbar",
+				giu.Button("Run"),
+			),
+			giu.ProfilerOverlay(),
+		}
+	})
+
+	// values are shown by the overlay, e.g. "toolbar: 0.105 ms"
+	value := func(prefix, format string) (v float64, found bool) {
+		for _, item := range h.Items() {
+			if rest, ok := strings.CutPrefix(item.Label, prefix); ok {
+				_, err := fmt.Sscanf(rest, format, &v)
+				assert.NoError(t, err, "unexpected format: %s", item.Label)
+
+				return v, true
+			}
+		}
+
+		return 0, false
+	}
+
+	// build times depend on the machine, so only check they were recorded
+	ms, ok := value("toolbar: ", "%f ms")
+	if assert.True(t, ok, "timing of Profile not shown") {
+		assert.GreaterOrEqual(t, ms, 0.0, "build time of Profile not recorded")
+	}
+
+	_, ok = value("Frame build time: ", "%f ms")
+	assert.True(t, ok, "frame time not shown")
+
+	_, ok = value("FPS: ", "%f")
+	assert.True(t, ok, "FPS not shown")
+}
@@ -0,0 +1,59 @@
+package giu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProfilerFrames(t *testing.T) {
+	p := newProfiler()
+	now := time.Now()
+
+	p.beginFrame(now)
+	assert.Empty(t, p.frames(), "frame recorded before it ended")
+
+	for i := 1; i <= profilerFrames+2; i++ {
+		// idle time between frames shouldn't be recorded
+		now = now.Add(time.Second)
+		p.beginFrame(now)
+		now = now.Add(time.Duration(i) * time.Millisecond)
+		p.endFrame(now)
+	}
+
+	frames := p.frames()
+	assert.Len(t, frames, profilerFrames, "unexpected number of frames")
+	assert.InDelta(t, 3.0, frames[0], 1e-9, "the oldest frames should be dropped")
+	assert.InDelta(t, float64(profilerFrames+2), frames[len(frames)-1], 1e-9, "the newest frame should be the last one")
+
+	p = newProfiler()
+	assert.Zero(t, p.averageFrameTime(), "unexpected average without frames")
+
+	p.beginFrame(now)
+	p.endFrame(now.Add(10 * time.Millisecond))
+	p.beginFrame(now.Add(time.Minute))
+	p.endFrame(now.Add(time.Minute + 20*time.Millisecond))
+	assert.InDelta(t, 15.0, p.averageFrameTime(), 1e-9, "unexpected average frame time")
+}
+
+func Test_ProfilerRecord(t *testing.T) {
+	p := newProfiler()
+	p.beginFrame(time.Now())
+
+	p.record("b", time.Millisecond)
+	p.record("a", time.Millisecond)
+	p.record("b", 2*time.Millisecond)
+
+	assert.Empty(t, p.lastOrder, "current frame reported before it ended")
+
+	p.beginFrame(time.Now())
+
+	assert.Equal(t, []string{"b", "a"}, p.lastOrder, "unexpected order")
+	assert.Equal(t, 3*time.Millisecond, p.last["b"], "times not summed")
+
+	p.beginFrame(time.Now())
+
+	assert.Empty(t, p.lastOrder, "timings of an older frame reported")
+	assert.Empty(t, p.last, "timings of an older frame reported")
+}
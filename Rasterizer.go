@@ -0,0 +1,209 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TextureLookup returns pixels of a texture with the given id.
+// It may return nil if texture is unknown (it is then treated as a plain white texture).
+type TextureLookup func(id imgui.TextureID) *image.RGBA
+
+// Rasterize renders draw data into a new image without using GPU.
+// It is a simple software rasterizer intended for snapshot testing
+// (see HeadlessBackend.Screenshot and giutest package):
+// - triangles are filled according to the top-left rule
+// - vertex colors are interpolated and multiplied by the texture (sampled with the nearest texel)
+// - results are alpha-blended (like imgui's renderer backends do)
+// - command's clip rectangles are respected
+//
+// The result is of size DisplaySize*FramebufferScale and is filled with bg before rendering.
+func (f *FrameDrawData) Rasterize(bg color.Color, textures TextureLookup) *image.RGBA {
+	scale := f.FramebufferScale
+	if scale.X == 0 || scale.Y == 0 {
+		scale = imgui.Vec2{X: 1, Y: 1}
+	}
+
+	width := int(f.DisplaySize.X * scale.X)
+	height := int(f.DisplaySize.Y * scale.Y)
+
+	result := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(result, result.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	for _, list := range f.Lists {
+		for _, cmd := range list.Commands {
+			clip := image.Rect(
+				int(math.Floor(float64((cmd.ClipRect.X-f.DisplayPos.X)*scale.X))),
+				int(math.Floor(float64((cmd.ClipRect.Y-f.DisplayPos.Y)*scale.Y))),
+				int(math.Ceil(float64((cmd.ClipRect.Z-f.DisplayPos.X)*scale.X))),
+				int(math.Ceil(float64((cmd.ClipRect.W-f.DisplayPos.Y)*scale.Y))),
+			).Intersect(result.Bounds())
+
+			if clip.Empty() {
+				continue
+			}
+
+			var tex *image.RGBA
+			if textures != nil {
+				tex = textures(cmd.TextureID)
+			}
+
+			for i := uint32(0); i+2 < cmd.ElemCount; i += 3 {
+				var tri [3]rasterVertex
+
+				for j := range tri {
+					v := list.Vertices[cmd.VtxOffset+list.Indices[cmd.IdxOffset+i+uint32(j)]]
+					tri[j] = rasterVertex{
+						x: (v.Pos.X - f.DisplayPos.X) * scale.X,
+						y: (v.Pos.Y - f.DisplayPos.Y) * scale.Y,
+						u: v.UV.X,
+						v: v.UV.Y,
+						col: [4]float32{
+							float32(v.Col&0xff) / 255,
+							float32(v.Col>>8&0xff) / 255,
+							float32(v.Col>>16&0xff) / 255,
+							float32(v.Col>>24&0xff) / 255,
+						},
+					}
+				}
+
+				rasterizeTriangle(result, clip, tex, tri)
+			}
+		}
+	}
+
+	return result
+}
+
+type rasterVertex struct {
+	x, y float32
+	u, v float32
+	col  [4]float32
+}
+
+// edge function: >0 if p is on the left side of a->b.
+func rasterEdge(ax, ay, bx, by, px, py float32) float32 {
+	return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+}
+
+// top-left rule: pixels lying exactly on the edge are drawn only for top and left edges,
+// so that pixels shared by two triangles are not blended twice.
+func rasterIsTopLeft(ax, ay, bx, by float32) bool {
+	return (ay == by && bx < ax) || by < ay
+}
+
+func rasterizeTriangle(dst *image.RGBA, clip image.Rectangle, tex *image.RGBA, tri [3]rasterVertex) {
+	area := rasterEdge(tri[0].x, tri[0].y, tri[1].x, tri[1].y, tri[2].x, tri[2].y)
+	if area == 0 {
+		return
+	}
+
+	// make winding consistent
+	if area < 0 {
+		tri[1], tri[2] = tri[2], tri[1]
+		area = -area
+	}
+
+	bounds := image.Rect(
+		int(math.Floor(float64(min(tri[0].x, tri[1].x, tri[2].x)))),
+		int(math.Floor(float64(min(tri[0].y, tri[1].y, tri[2].y)))),
+		int(math.Ceil(float64(max(tri[0].x, tri[1].x, tri[2].x))))+1,
+		int(math.Ceil(float64(max(tri[0].y, tri[1].y, tri[2].y))))+1,
+	).Intersect(clip)
+
+	var topLeft [3]bool
+	for i := range tri {
+		a, b := tri[(i+1)%3], tri[(i+2)%3]
+		topLeft[i] = rasterIsTopLeft(a.x, a.y, b.x, b.y)
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px, py := float32(x)+0.5, float32(y)+0.5
+
+			var w [3]float32
+
+			inside := true
+
+			for i := range tri {
+				a, b := tri[(i+1)%3], tri[(i+2)%3]
+				w[i] = rasterEdge(a.x, a.y, b.x, b.y, px, py)
+
+				if w[i] < 0 || (w[i] == 0 && !topLeft[i]) {
+					inside = false
+					break
+				}
+			}
+
+			if !inside {
+				continue
+			}
+
+			for i := range w {
+				w[i] /= area
+			}
+
+			var col [4]float32
+			for c := range col {
+				col[c] = w[0]*tri[0].col[c] + w[1]*tri[1].col[c] + w[2]*tri[2].col[c]
+			}
+
+			if tex != nil {
+				u := w[0]*tri[0].u + w[1]*tri[1].u + w[2]*tri[2].u
+				v := w[0]*tri[0].v + w[1]*tri[1].v + w[2]*tri[2].v
+				texel := rasterSample(tex, u, v)
+
+				for c := range col {
+					col[c] *= texel[c]
+				}
+			}
+
+			rasterBlend(dst, x, y, col)
+		}
+	}
+}
+
+// rasterSample samples texture at (u, v) using the nearest texel.
+func rasterSample(tex *image.RGBA, u, v float32) [4]float32 {
+	b := tex.Bounds()
+	if b.Empty() {
+		return [4]float32{1, 1, 1, 1}
+	}
+
+	x := min(max(int(u*float32(b.Dx())), 0), b.Dx()-1) + b.Min.X
+	y := min(max(int(v*float32(b.Dy())), 0), b.Dy()-1) + b.Min.Y
+	c := tex.RGBAAt(x, y)
+
+	// NOTE: pixels are used as-is (like GPU backends do when uploading image.RGBA)
+	return [4]float32{
+		float32(c.R) / 255,
+		float32(c.G) / 255,
+		float32(c.B) / 255,
+		float32(c.A) / 255,
+	}
+}
+
+// rasterBlend does src-over blending (SRC_ALPHA, ONE_MINUS_SRC_ALPHA) of col onto dst.
+func rasterBlend(dst *image.RGBA, x, y int, col [4]float32) {
+	srcA := min(max(col[3], 0), 1)
+	if srcA == 0 {
+		return
+	}
+
+	d := dst.RGBAAt(x, y)
+	blend := func(src float32, dstV uint8) uint8 {
+		v := min(max(src, 0), 1)*srcA*255 + float32(dstV)*(1-srcA)
+		return uint8(min(v+0.5, 255))
+	}
+
+	dst.SetRGBA(x, y, color.RGBA{
+		R: blend(col[0], d.R),
+		G: blend(col[1], d.G),
+		B: blend(col[2], d.B),
+		A: uint8(min(srcA*255+float32(d.A)*(1-srcA)+0.5, 255)),
+	})
+}
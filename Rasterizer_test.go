@@ -0,0 +1,54 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/stretchr/testify/assert"
+)
+
+// a square (2,2)-(8,8) made of two triangles.
+func testSquare(col uint32, clip imgui.Vec4) *FrameDrawData {
+	return &FrameDrawData{
+		DisplaySize:      imgui.Vec2{X: 10, Y: 10},
+		FramebufferScale: imgui.Vec2{X: 1, Y: 1},
+		Lists: []FrameDrawList{
+			{
+				Vertices: []FrameVertex{
+					{Pos: imgui.Vec2{X: 2, Y: 2}, Col: col},
+					{Pos: imgui.Vec2{X: 8, Y: 2}, Col: col},
+					{Pos: imgui.Vec2{X: 8, Y: 8}, Col: col},
+					{Pos: imgui.Vec2{X: 2, Y: 8}, Col: col},
+				},
+				Indices:  []uint32{0, 1, 2, 0, 2, 3},
+				Commands: []FrameDrawCmd{{ClipRect: clip, ElemCount: 6}},
+			},
+		},
+	}
+}
+
+func Test_FrameDrawData_Rasterize(t *testing.T) {
+	tests := []struct {
+		name     string
+		col      uint32
+		clip     imgui.Vec4
+		pt       image.Point
+		expected color.RGBA
+	}{
+		{"outside", 0xff0000ff, imgui.Vec4{X: 0, Y: 0, Z: 10, W: 10}, image.Pt(1, 1), color.RGBA{0, 0, 0, 255}},
+		{"opaque red", 0xff0000ff, imgui.Vec4{X: 0, Y: 0, Z: 10, W: 10}, image.Pt(2, 2), color.RGBA{255, 0, 0, 255}},
+		{"diagonal is not blended twice", 0x80ffffff, imgui.Vec4{X: 0, Y: 0, Z: 10, W: 10}, image.Pt(5, 5), color.RGBA{128, 128, 128, 255}},
+		{"right edge excluded", 0xff0000ff, imgui.Vec4{X: 0, Y: 0, Z: 10, W: 10}, image.Pt(8, 5), color.RGBA{0, 0, 0, 255}},
+		{"clipped", 0xff0000ff, imgui.Vec4{X: 0, Y: 0, Z: 5, W: 5}, image.Pt(6, 6), color.RGBA{0, 0, 0, 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := testSquare(tt.col, tt.clip).Rasterize(color.Black, nil)
+			assert.Equal(t, image.Rect(0, 0, 10, 10), img.Bounds(), "unexpected image size")
+			assert.Equal(t, tt.expected, img.RGBAAt(tt.pt.X, tt.pt.Y), "unexpected pixel color")
+		})
+	}
+}
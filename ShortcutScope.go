@@ -0,0 +1,92 @@
+package giu
+
+import (
+	"cmp"
+	"slices"
+)
+
+// activeScope is a focused scope and its nesting depth.
+type activeScope struct {
+	name  string
+	depth int
+}
+
+// shortcutScopes tracks scopes of key bindings (see ShortcutScope).
+type shortcutScopes struct {
+	// stack are names of scopes being built
+	stack []string
+	// current are scopes focused in the current frame
+	current []activeScope
+	// active are names of scopes focused in the last frame (the innermost first)
+	active []string
+}
+
+// begin enters the scope name. focused tells if the window of the scope (or its child) is focused.
+func (s *shortcutScopes) begin(name string, focused bool) {
+	s.stack = append(s.stack, name)
+
+	if focused {
+		s.current = append(s.current, activeScope{name, len(s.stack)})
+	}
+}
+
+func (s *shortcutScopes) end() {
+	if len(s.stack) > 0 {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+}
+
+// endFrame makes scopes focused in the current frame active.
+func (s *shortcutScopes) endFrame() {
+	slices.SortStableFunc(s.current, func(a, b activeScope) int {
+		return cmp.Compare(b.depth, a.depth)
+	})
+
+	s.active = s.active[:0]
+	for _, scope := range s.current {
+		s.active = append(s.active, scope.name)
+	}
+
+	s.current = s.current[:0]
+	s.stack = s.stack[:0]
+}
+
+// activeShortcutScopes returns names of scopes focused in the last frame.
+func activeShortcutScopes() []string {
+	if Context == nil || Context.shortcutScopes == nil {
+		return nil
+	}
+
+	return slices.Clone(Context.shortcutScopes.active)
+}
+
+var _ Widget = &ShortcutScopeWidget{}
+
+// ShortcutScopeWidget makes key bindings with its scope (see KeyBinding.Scope) active
+// while the window it is built in (e.g. Child or Popup) or a child of that window is focused.
+// Scopes nest: bindings of inner scopes take priority over the outer ones and over global bindings.
+// NOTE: WindowWidget.ShortcutScope makes a window a scope.
+type ShortcutScopeWidget struct {
+	name   string
+	layout Layout
+}
+
+// ShortcutScope creates a new ShortcutScopeWidget, e.g.:
+//
+//	giu.Child().Layout(
+//		giu.ShortcutScope("editor", widgets...),
+//	)
+func ShortcutScope(name string, widgets ...Widget) *ShortcutScopeWidget {
+	return &ShortcutScopeWidget{
+		name:   name,
+		layout: widgets,
+	}
+}
+
+// Build implements Widget interface.
+func (s *ShortcutScopeWidget) Build() {
+	Context.shortcutScopes.begin(s.name, IsWindowFocused(FocusedFlagsChildWindows))
+	defer Context.shortcutScopes.end()
+
+	s.layout.Build()
+}
@@ -1,8 +1,6 @@
 package giu
 
 import (
-	"fmt"
-
 	"github.com/AllenDang/cimgui-go/imgui"
 )
 
@@ -17,6 +15,9 @@ type SliderIntWidget struct {
 	format   string
 	width    float32
 	onChange func()
+	undo     *UndoStack
+
+	cssAttributes
 }
 
 // SliderInt constructs new SliderIntWidget.
@@ -53,6 +54,12 @@ func (s *SliderIntWidget) OnChange(onChange func()) *SliderIntWidget {
 	return s
 }
 
+// UndoStack sets stack recording changes of the slider's value (see UndoStack).
+func (s *SliderIntWidget) UndoStack(stack *UndoStack) *SliderIntWidget {
+	s.undo = stack
+	return s
+}
+
 // Label sets slider label (id).
 func (s *SliderIntWidget) Label(label string) *SliderIntWidget {
 	s.label = GenAutoID(label)
@@ -61,7 +68,7 @@ func (s *SliderIntWidget) Label(label string) *SliderIntWidget {
 
 // Labelf sets formatted label.
 func (s *SliderIntWidget) Labelf(format string, args ...any) *SliderIntWidget {
-	return s.Label(fmt.Sprintf(format, args...))
+	return s.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID manually sets widget id.
@@ -70,6 +77,18 @@ func (s *SliderIntWidget) ID(id ID) *SliderIntWidget {
 	return s
 }
 
+// Class adds CSS classes to the slider int (matched by .class selectors).
+func (s *SliderIntWidget) Class(classes ...string) *SliderIntWidget {
+	s.addClasses(classes)
+	return s
+}
+
+// CSSID sets CSS ID of the slider int (matched by #id selectors).
+func (s *SliderIntWidget) CSSID(id string) *SliderIntWidget {
+	s.cssID = id
+	return s
+}
+
 // Build implements Widget interface.
 func (s *SliderIntWidget) Build() {
 	if s.width != 0 {
@@ -78,7 +97,11 @@ func (s *SliderIntWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.SliderIntV(Context.PrepareString(s.label.String()), s.value, s.minValue, s.maxValue, s.format, 0) && s.onChange != nil {
+	before := *s.value
+	isChanged := imgui.SliderIntV(Context.PrepareString(s.label.String()), s.value, s.minValue, s.maxValue, s.format, 0)
+	trackEdit(s.undo, s.label, s.value, before, nil)
+
+	if isChanged && s.onChange != nil {
 		s.onChange()
 	}
 }
@@ -144,7 +167,7 @@ func (vs *VSliderIntWidget) Label(label string) *VSliderIntWidget {
 
 // Labelf sets formatted label.
 func (vs *VSliderIntWidget) Labelf(format string, args ...any) *VSliderIntWidget {
-	return vs.Label(fmt.Sprintf(format, args...))
+	return vs.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID manually sets widget id.
@@ -180,6 +203,12 @@ type SliderFloatWidget struct {
 	format   string
 	width    float32
 	onChange func()
+	undo     *UndoStack
+
+	// bind writes value back to the bound Observable (see SliderFloatBound)
+	bind func()
+
+	cssAttributes
 }
 
 // SliderFloat creates new slider float widget.
@@ -209,6 +238,12 @@ func (sf *SliderFloatWidget) OnChange(onChange func()) *SliderFloatWidget {
 	return sf
 }
 
+// UndoStack sets stack recording changes of the slider's value (see UndoStack).
+func (sf *SliderFloatWidget) UndoStack(stack *UndoStack) *SliderFloatWidget {
+	sf.undo = stack
+	return sf
+}
+
 // Size sets slider's width.
 func (sf *SliderFloatWidget) Size(width float32) *SliderFloatWidget {
 	sf.width = width
@@ -223,7 +258,7 @@ func (sf *SliderFloatWidget) Label(label string) *SliderFloatWidget {
 
 // Labelf sets formatted label.
 func (sf *SliderFloatWidget) Labelf(format string, args ...any) *SliderFloatWidget {
-	return sf.Label(fmt.Sprintf(format, args...))
+	return sf.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID manually sets widget id.
@@ -232,6 +267,18 @@ func (sf *SliderFloatWidget) ID(id ID) *SliderFloatWidget {
 	return sf
 }
 
+// Class adds CSS classes to the slider float (matched by .class selectors).
+func (sf *SliderFloatWidget) Class(classes ...string) *SliderFloatWidget {
+	sf.addClasses(classes)
+	return sf
+}
+
+// CSSID sets CSS ID of the slider float (matched by #id selectors).
+func (sf *SliderFloatWidget) CSSID(id string) *SliderFloatWidget {
+	sf.cssID = id
+	return sf
+}
+
 // Build implements Widget interface.
 func (sf *SliderFloatWidget) Build() {
 	if sf.width != 0 {
@@ -240,7 +287,19 @@ func (sf *SliderFloatWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.SliderFloatV(Context.PrepareString(sf.label.String()), sf.value, sf.minValue, sf.maxValue, sf.format, 1.0) && sf.onChange != nil {
+	before := *sf.value
+	isChanged := imgui.SliderFloatV(Context.PrepareString(sf.label.String()), sf.value, sf.minValue, sf.maxValue, sf.format, 1.0)
+	trackEdit(sf.undo, sf.label, sf.value, before, sf.bind)
+
+	if !isChanged {
+		return
+	}
+
+	if sf.bind != nil {
+		sf.bind()
+	}
+
+	if sf.onChange != nil {
 		sf.onChange()
 	}
 }
@@ -257,8 +316,11 @@ type DragIntWidget struct {
 	maxValue int32
 	format   string
 	onChange func()
+	undo     *UndoStack
 	flags    SliderFlags
 	width    float32
+
+	cssAttributes
 }
 
 // DragInt creates new DragIntWidget.
@@ -282,7 +344,7 @@ func (d *DragIntWidget) Label(label string) *DragIntWidget {
 
 // Labelf sets formatted label.
 func (d *DragIntWidget) Labelf(format string, args ...any) *DragIntWidget {
-	return d.Label(fmt.Sprintf(format, args...))
+	return d.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID manually sets widget id.
@@ -328,12 +390,30 @@ func (d *DragIntWidget) OnChange(onChange func()) *DragIntWidget {
 	return d
 }
 
+// UndoStack sets stack recording changes of the drag int's value (see UndoStack).
+func (d *DragIntWidget) UndoStack(stack *UndoStack) *DragIntWidget {
+	d.undo = stack
+	return d
+}
+
 // Size sets slider's width.
 func (d *DragIntWidget) Size(width float32) *DragIntWidget {
 	d.width = width
 	return d
 }
 
+// Class adds CSS classes to the drag int (matched by .class selectors).
+func (d *DragIntWidget) Class(classes ...string) *DragIntWidget {
+	d.addClasses(classes)
+	return d
+}
+
+// CSSID sets CSS ID of the drag int (matched by #id selectors).
+func (d *DragIntWidget) CSSID(id string) *DragIntWidget {
+	d.cssID = id
+	return d
+}
+
 // Build implements Widget interface.
 func (d *DragIntWidget) Build() {
 	if d.width != 0 {
@@ -342,7 +422,11 @@ func (d *DragIntWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.DragIntV(Context.PrepareString(d.label.String()), d.value, d.speed, d.minValue, d.maxValue, d.format, imgui.SliderFlags(d.flags)) && d.onChange != nil {
+	before := *d.value
+	isChanged := imgui.DragIntV(Context.PrepareString(d.label.String()), d.value, d.speed, d.minValue, d.maxValue, d.format, imgui.SliderFlags(d.flags))
+	trackEdit(d.undo, d.label, d.value, before, nil)
+
+	if isChanged && d.onChange != nil {
 		d.onChange()
 	}
 }
@@ -358,8 +442,11 @@ type DragFloatWidget struct {
 	maxValue float32
 	format   string
 	onChange func()
+	undo     *UndoStack
 	flags    SliderFlags
 	width    float32
+
+	cssAttributes
 }
 
 // DragFloat creates new DragFloatWidget.
@@ -383,7 +470,7 @@ func (d *DragFloatWidget) Label(label string) *DragFloatWidget {
 
 // Labelf sets formatted label.
 func (d *DragFloatWidget) Labelf(format string, args ...any) *DragFloatWidget {
-	return d.Label(fmt.Sprintf(format, args...))
+	return d.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID manually sets widget id.
@@ -429,12 +516,30 @@ func (d *DragFloatWidget) OnChange(onChange func()) *DragFloatWidget {
 	return d
 }
 
+// UndoStack sets stack recording changes of the drag float's value (see UndoStack).
+func (d *DragFloatWidget) UndoStack(stack *UndoStack) *DragFloatWidget {
+	d.undo = stack
+	return d
+}
+
 // Size sets slider's width.
 func (d *DragFloatWidget) Size(width float32) *DragFloatWidget {
 	d.width = width
 	return d
 }
 
+// Class adds CSS classes to the drag float (matched by .class selectors).
+func (d *DragFloatWidget) Class(classes ...string) *DragFloatWidget {
+	d.addClasses(classes)
+	return d
+}
+
+// CSSID sets CSS ID of the drag float (matched by #id selectors).
+func (d *DragFloatWidget) CSSID(id string) *DragFloatWidget {
+	d.cssID = id
+	return d
+}
+
 // Build implements Widget interface.
 func (d *DragFloatWidget) Build() {
 	if d.width != 0 {
@@ -443,7 +548,11 @@ func (d *DragFloatWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.DragFloatV(Context.PrepareString(d.label.String()), d.value, d.speed, d.minValue, d.maxValue, d.format, imgui.SliderFlags(d.flags)) && d.onChange != nil {
+	before := *d.value
+	isChanged := imgui.DragFloatV(Context.PrepareString(d.label.String()), d.value, d.speed, d.minValue, d.maxValue, d.format, imgui.SliderFlags(d.flags))
+	trackEdit(d.undo, d.label, d.value, before, nil)
+
+	if isChanged && d.onChange != nil {
 		d.onChange()
 	}
 }
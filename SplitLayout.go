@@ -182,30 +182,34 @@ func (s *SplitLayoutWidget) encodeSashPos(sashPos, availableW, availableH float3
 	}
 }
 
-func (s *SplitLayoutWidget) restoreItemSpacing(layout Widget) Layout {
-	return Layout{
-		Custom(func() {
-			PushItemSpacing(s.originItemSpacingX, s.originItemSpacingY)
-			PushFramePadding(s.originFramePaddingX, s.originFramePaddingY)
-			// Restore Child bg color
-			bgColor := imgui.StyleColorVec4(imgui.ColChildBg)
-			PushStyleColor(StyleColorChildBg, Vec4ToRGBA(*bgColor))
-		}),
+// Build Child panel.
+func (s *SplitLayoutWidget) buildChild(width, height float32, layout Widget) Widget {
+	return splitChild(width, height, s.border,
+		imgui.Vec2{X: s.originItemSpacingX, Y: s.originItemSpacingY},
+		imgui.Vec2{X: s.originFramePaddingX, Y: s.originFramePaddingY},
 		layout,
-		Custom(func() {
-			PopStyleColor()
-			PopStyleV(2)
-		}),
-	}
+	)
 }
 
-// Build Child panel. If layout is a SplitLayout, set the frame padding to zero.
-func (s *SplitLayoutWidget) buildChild(width, height float32, layout Widget) Widget {
+// splitChild creates a child panel of a split layout. itemSpacing and framePadding are restored inside of it.
+// If layout is a nested split layout (possibly wrapped in SplitPane), the frame padding is set to zero.
+func splitChild(width, height float32, border bool, itemSpacing, framePadding imgui.Vec2, layout Widget) Widget {
 	return Layout{
 		Custom(func() {
-			_, isSplitLayoutWidget := layout.(*SplitLayoutWidget)
-			hasFramePadding := isSplitLayoutWidget || !s.border
-			hasBorder := !isSplitLayoutWidget && s.border
+			var isSplitLayoutWidget bool
+
+			inner := layout
+			if pane, ok := inner.(*SplitPaneWidget); ok {
+				inner = pane.widget
+			}
+
+			switch inner.(type) {
+			case *SplitLayoutWidget, *NSplitWidget:
+				isSplitLayoutWidget = true
+			}
+
+			hasFramePadding := isSplitLayoutWidget || !border
+			hasBorder := !isSplitLayoutWidget && border
 
 			if hasFramePadding {
 				PushFramePadding(0, 0)
@@ -216,7 +220,7 @@ func (s *SplitLayoutWidget) buildChild(width, height float32, layout Widget) Wid
 			Child().
 				Border(hasBorder).
 				Size(width, height).
-				Layout(s.restoreItemSpacing(layout)).
+				Layout(splitRestoreStyle(itemSpacing, framePadding, layout)).
 				Build()
 
 			PopStyleColor()
@@ -228,6 +232,23 @@ func (s *SplitLayoutWidget) buildChild(width, height float32, layout Widget) Wid
 	}
 }
 
+func splitRestoreStyle(itemSpacing, framePadding imgui.Vec2, layout Widget) Layout {
+	return Layout{
+		Custom(func() {
+			PushItemSpacing(itemSpacing.X, itemSpacing.Y)
+			PushFramePadding(framePadding.X, framePadding.Y)
+			// Restore Child bg color
+			bgColor := imgui.StyleColorVec4(imgui.ColChildBg)
+			PushStyleColor(StyleColorChildBg, Vec4ToRGBA(*bgColor))
+		}),
+		layout,
+		Custom(func() {
+			PopStyleColor()
+			PopStyleV(2)
+		}),
+	}
+}
+
 func (s *SplitLayoutWidget) getState() (state *splitLayoutState) {
 	if state = GetState[splitLayoutState](Context, s.id); state == nil {
 		state = &splitLayoutState{delta: 0.0}
@@ -0,0 +1,354 @@
+package giu
+
+import (
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+type styleEditorState struct {
+	filter string
+}
+
+func (s *styleEditorState) Dispose() {
+	// noop
+}
+
+var _ Widget = &StyleEditorWidget{}
+
+// StyleEditorWidget lets user edit all colors and style variables of a StyleSetter live.
+// Values which are not set in the target are shown in gray and
+// (for colors) initialized from the current style; the "x" button unsets a value.
+type StyleEditorWidget struct {
+	id       ID
+	target   *StyleSetter
+	onChange func()
+}
+
+// StyleEditor creates a new StyleEditorWidget editing target.
+// Changes are written to target immediately, so when it is used to style the UI
+// (e.g. as a main tag of CSS stylesheet or via To(...)), they are visible in the next frame.
+func StyleEditor(target *StyleSetter) *StyleEditorWidget {
+	return &StyleEditorWidget{
+		id:     GenAutoID("##StyleEditor"),
+		target: target,
+	}
+}
+
+// ID assigns hardcoded ID (baypass GenAutoID mechanism).
+func (se *StyleEditorWidget) ID(id ID) *StyleEditorWidget {
+	se.id = id
+	return se
+}
+
+// OnChange sets callback called whenever user changes the target style.
+func (se *StyleEditorWidget) OnChange(onChange func()) *StyleEditorWidget {
+	se.onChange = onChange
+	return se
+}
+
+// Build implements Widget interface.
+func (se *StyleEditorWidget) Build() {
+	var state *styleEditorState
+	if state = GetState[styleEditorState](Context, se.id); state == nil {
+		state = &styleEditorState{}
+		SetState(Context, se.id, state)
+	}
+
+	se.target.initMaps()
+
+	filter := strings.ToLower(strings.TrimSpace(state.filter))
+	matches := func(name string) bool {
+		return filter == "" || strings.Contains(name, filter)
+	}
+
+	imgui.PushIDStr(se.id.String())
+	defer imgui.PopID()
+
+	Layout{
+		Row(
+			Button("Reset to DefaultTheme").OnClick(func() {
+				se.target.resetTo(DefaultTheme())
+				se.changed()
+			}),
+			Button("Reset to LightTheme").OnClick(func() {
+				se.target.resetTo(LightTheme())
+				se.changed()
+			}),
+		),
+		InputText(&state.filter).Hint("Filter").Size(-1),
+		TabBar().TabItems(
+			TabItem("Colors").Layout(Child().Layout(Custom(func() {
+				for _, id := range StyleColorIDValues() {
+					if matches(id.String()) {
+						se.buildColor(id)
+					}
+				}
+			}))),
+			TabItem("Style variables").Layout(Child().Layout(Custom(func() {
+				for _, id := range StyleVarIDValues() {
+					if matches(id.String()) {
+						se.buildStyleVar(id)
+					}
+				}
+			}))),
+			TabItem("Plot colors").Layout(Child().Layout(Custom(func() {
+				for _, id := range StylePlotColorIDValues() {
+					if matches(id.String()) {
+						se.buildPlotColor(id)
+					}
+				}
+			}))),
+			TabItem("Plot style variables").Layout(Child().Layout(Custom(func() {
+				for _, id := range StylePlotVarIDValues() {
+					if id != StylePlotVarCOUNT && matches(id.String()) {
+						se.buildPlotStyleVar(id)
+					}
+				}
+			}))),
+		),
+	}.Build()
+}
+
+func (se *StyleEditorWidget) changed() {
+	if se.onChange != nil {
+		se.onChange()
+	}
+}
+
+func (se *StyleEditorWidget) buildColor(id StyleColorID) {
+	col, isSet := se.target.colors[id]
+	if !isSet {
+		col = *imgui.StyleColorVec4(imgui.Col(id))
+	}
+
+	se.buildEntry(id.String(), isSet, func(label string) bool {
+		v := [4]float32{col.X, col.Y, col.Z, col.W}
+		if !imgui.ColorEdit4V(label, &v, imgui.ColorEditFlags(ColorEditFlagsAlphaBar)) {
+			return false
+		}
+
+		se.target.SetColorVec4(id, imgui.Vec4{X: v[0], Y: v[1], Z: v[2], W: v[3]})
+
+		return true
+	}, func() {
+		delete(se.target.colors, id)
+	})
+}
+
+func (se *StyleEditorWidget) buildPlotColor(id StylePlotColorID) {
+	col, isSet := se.target.plotColors[id]
+
+	var c imgui.Vec4
+	if isSet {
+		c = plotColorToVec4(col)
+	}
+
+	se.buildEntry(id.String(), isSet, func(label string) bool {
+		v := [4]float32{c.X, c.Y, c.Z, c.W}
+		if !imgui.ColorEdit4V(label, &v, imgui.ColorEditFlags(ColorEditFlagsAlphaBar)) {
+			return false
+		}
+
+		se.target.SetPlotColor(id, vec4ToPlotColor(imgui.Vec4{X: v[0], Y: v[1], Z: v[2], W: v[3]}))
+
+		return true
+	}, func() {
+		delete(se.target.plotColors, id)
+	})
+}
+
+func (se *StyleEditorWidget) buildStyleVar(id StyleVarID) {
+	_, isSet := se.target.styles[id]
+
+	se.buildEntry(id.String(), isSet, func(label string) bool {
+		if id.IsVec2() {
+			x, y := se.target.GetStyle(id)
+			if !isSet {
+				v := currentStyleVar(id)
+				x, y = v.X, v.Y
+			}
+
+			return dragStyleVec2(label, x, y, func(x, y float32) { se.target.SetStyle(id, x, y) })
+		}
+
+		v := se.target.GetStyleFloat(id)
+		if !isSet {
+			v = currentStyleVar(id).X
+		}
+
+		return dragStyleFloat(label, v, func(v float32) { se.target.SetStyleFloat(id, v) })
+	}, func() {
+		delete(se.target.styles, id)
+	})
+}
+
+func (se *StyleEditorWidget) buildPlotStyleVar(id StylePlotVarID) {
+	_, isSet := se.target.plotStyles[id]
+
+	se.buildEntry(id.String(), isSet, func(label string) bool {
+		if id.IsVec2() {
+			x, y := se.target.GetPlotStyle(id)
+			return dragStyleVec2(label, x, y, func(x, y float32) { se.target.SetPlotStyle(id, x, y) })
+		}
+
+		return dragStyleFloat(label, se.target.GetPlotStyleFloat(id), func(v float32) { se.target.SetPlotStyleFloat(id, v) })
+	}, func() {
+		delete(se.target.plotStyles, id)
+	})
+}
+
+// currentStyleVar returns value of id in the current imgui style (float variables are returned in X).
+//
+//nolint:gocyclo,cyclop // it is just a long switch
+func currentStyleVar(id StyleVarID) imgui.Vec2 {
+	style := imgui.CurrentStyle()
+	float := func(v float32) imgui.Vec2 { return imgui.Vec2{X: v} }
+
+	switch id {
+	case StyleVarAlpha:
+		return float(style.Alpha())
+	case StyleVarDisabledAlpha:
+		return float(style.DisabledAlpha())
+	case StyleVarWindowPadding:
+		return style.WindowPadding()
+	case StyleVarWindowRounding:
+		return float(style.WindowRounding())
+	case StyleVarWindowBorderSize:
+		return float(style.WindowBorderSize())
+	case StyleVarWindowMinSize:
+		return style.WindowMinSize()
+	case StyleVarWindowTitleAlign:
+		return style.WindowTitleAlign()
+	case StyleVarChildRounding:
+		return float(style.ChildRounding())
+	case StyleVarChildBorderSize:
+		return float(style.ChildBorderSize())
+	case StyleVarPopupRounding:
+		return float(style.PopupRounding())
+	case StyleVarPopupBorderSize:
+		return float(style.PopupBorderSize())
+	case StyleVarFramePadding:
+		return style.FramePadding()
+	case StyleVarFrameRounding:
+		return float(style.FrameRounding())
+	case StyleVarFrameBorderSize:
+		return float(style.FrameBorderSize())
+	case StyleVarItemSpacing:
+		return style.ItemSpacing()
+	case StyleVarItemInnerSpacing:
+		return style.ItemInnerSpacing()
+	case StyleVarIndentSpacing:
+		return float(style.IndentSpacing())
+	case StyleVarCellPadding:
+		return style.CellPadding()
+	case StyleVarScrollbarSize:
+		return float(style.ScrollbarSize())
+	case StyleVarScrollbarRounding:
+		return float(style.ScrollbarRounding())
+	case StyleVarGrabMinSize:
+		return float(style.GrabMinSize())
+	case StyleVarGrabRounding:
+		return float(style.GrabRounding())
+	case StyleVarTabRounding:
+		return float(style.TabRounding())
+	case StyleVarTabBarBorderSize:
+		return float(style.TabBarBorderSize())
+	case StyleVarButtonTextAlign:
+		return style.ButtonTextAlign()
+	case StyleVarSelectableTextAlign:
+		return style.SelectableTextAlign()
+	case StyleVarSeparatorTextBorderSize:
+		return float(style.SeparatorTextBorderSize())
+	case StyleVarSeparatorTextAlign:
+		return style.SeparatorTextAlign()
+	case StyleVarSeparatorTextPadding:
+		return style.SeparatorTextPadding()
+	case StyleVarDockingSeparatorSize:
+		return float(style.DockingSeparatorSize())
+	default:
+		return imgui.Vec2{}
+	}
+}
+
+// buildEntry builds editor of a single value (edit returns true when the value was changed)
+// followed by a button which calls unset.
+func (se *StyleEditorWidget) buildEntry(name string, isSet bool, edit func(label string) bool, unset func()) {
+	imgui.PushIDStr(name)
+	defer imgui.PopID()
+
+	if !isSet {
+		imgui.PushStyleColorVec4(imgui.ColText, *imgui.StyleColorVec4(imgui.ColTextDisabled))
+	}
+
+	changed := edit(Context.PrepareString(name))
+
+	if !isSet {
+		imgui.PopStyleColor()
+	}
+
+	if isSet {
+		imgui.SameLine()
+
+		if imgui.SmallButton("x") {
+			unset()
+
+			changed = true
+		}
+
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip("Unset " + name)
+		}
+	}
+
+	if changed {
+		se.changed()
+	}
+}
+
+func dragStyleFloat(label string, v float32, set func(v float32)) bool {
+	if !imgui.DragFloatV(label, &v, 0.05, 0, 0, "%.2f", 0) {
+		return false
+	}
+
+	set(v)
+
+	return true
+}
+
+func dragStyleVec2(label string, x, y float32, set func(x, y float32)) bool {
+	v := [2]float32{x, y}
+	if !imgui.DragFloat2V(label, &v, 0.05, 0, 0, "%.2f", 0) {
+		return false
+	}
+
+	set(v[0], v[1])
+
+	return true
+}
+
+// resetTo replaces all colors, style variables, plot colors and plot style variables of ss
+// with a copy of these from other. Font, font size, disabled flag and layout of ss are kept.
+func (ss *StyleSetter) resetTo(other *StyleSetter) {
+	ss.colors = nil
+	ss.styles = nil
+	ss.plotColors = nil
+	ss.plotStyles = nil
+	ss.initMaps()
+
+	for k, v := range other.colors {
+		ss.colors[k] = v
+	}
+
+	for k, v := range other.styles {
+		ss.styles[k] = v
+	}
+
+	for k, v := range other.plotColors {
+		ss.plotColors[k] = v
+	}
+
+	for k, v := range other.plotStyles {
+		ss.plotStyles[k] = v
+	}
+}
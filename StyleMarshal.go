@@ -0,0 +1,362 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+	"github.com/mazznoer/csscolorparser"
+)
+
+// MarshalCSS writes style as a CSS rule for selector (e.g. MainTag).
+// All values are written so that UnmarshalCSS (or CSSStylesheet.Parse) restores them exactly.
+// NOTE: font is written as font-family, so it must be added to FontAtlas to be loaded back.
+func (ss *StyleSetter) MarshalCSS(selector string) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%s {\n", selector)
+
+	for _, id := range slices.Sorted(maps.Keys(ss.colors)) {
+		fmt.Fprintf(&buf, "\t%s: %s;\n", id, cssColor(ss.colors[id]))
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(ss.styles)) {
+		fmt.Fprintf(&buf, "\t%s: %s;\n", id, cssStyleValue(ss.styles[id]))
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(ss.plotColors)) {
+		fmt.Fprintf(&buf, "\t%s: %s;\n", id, cssColor(plotColorToVec4(ss.plotColors[id])))
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(ss.plotStyles)) {
+		fmt.Fprintf(&buf, "\t%s: %s;\n", id, cssStyleValue(ss.plotStyles[id]))
+	}
+
+	if ss.font != nil && ss.font.fontName != "" {
+		fmt.Fprintf(&buf, "\tfont-family: %q;\n", ss.font.fontName)
+	}
+
+	if ss.fontSize != 0 {
+		fmt.Fprintf(&buf, "\tfont-size: %s;\n", cssFloat(ss.fontSize))
+	}
+
+	if ss.disabled {
+		buf.WriteString("\tdisabled: true;\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes()
+}
+
+// UnmarshalCSS loads style from CSS (as written by MarshalCSS) on top of the current values.
+// data may be either a stylesheet (all rules are applied in order, regardless of their selectors)
+// or bare declarations (e.g. "alpha: 0.5; color: red;").
+func (ss *StyleSetter) UnmarshalCSS(data []byte) error {
+	if !bytes.Contains(data, []byte("{")) {
+		data = append(append([]byte("* {\n"), data...), []byte("\n}")...)
+	}
+
+	stylesheet := CSS()
+	if err := stylesheet.Parse(data); err != nil {
+		return err
+	}
+
+	ss.initMaps()
+
+	// Add resets layout
+	layout := ss.layout
+
+	for _, rule := range stylesheet.rules {
+		ss.Add(rule.style)
+	}
+
+	ss.layout = layout
+
+	return nil
+}
+
+// Marshal writes all rules of the stylesheet (and its custom properties in :root rule).
+// The result can be loaded back by Parse.
+func (c *CSSStylesheet) Marshal() []byte {
+	var buf bytes.Buffer
+
+	if len(c.variables) > 0 {
+		buf.WriteString(":root {\n")
+
+		for _, name := range slices.Sorted(maps.Keys(c.variables)) {
+			fmt.Fprintf(&buf, "\t%s: %s;\n", name, c.variables[name])
+		}
+
+		buf.WriteString("}\n")
+	}
+
+	for i, rule := range c.rules {
+		if i > 0 || buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+
+		buf.Write(rule.style.MarshalCSS(rule.selector.String()))
+	}
+
+	return buf.Bytes()
+}
+
+// styleSetterJSON is a JSON representation of StyleSetter.
+// Keys of maps are CSS names (e.g. "button-color").
+type styleSetterJSON struct {
+	Colors     map[string][4]float32      `json:"colors,omitempty"`
+	Styles     map[string]json.RawMessage `json:"styles,omitempty"`
+	PlotColors map[string][4]float32      `json:"plotColors,omitempty"`
+	PlotStyles map[string]json.RawMessage `json:"plotStyles,omitempty"`
+	Font       string                     `json:"font,omitempty"`
+	FontSize   float32                    `json:"fontSize,omitempty"`
+	Disabled   bool                       `json:"disabled,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// Colors are written as [r, g, b, a] (0-1), style variables as a number or [x, y].
+func (ss *StyleSetter) MarshalJSON() ([]byte, error) {
+	result := styleSetterJSON{
+		Colors:     make(map[string][4]float32, len(ss.colors)),
+		Styles:     make(map[string]json.RawMessage, len(ss.styles)),
+		PlotColors: make(map[string][4]float32, len(ss.plotColors)),
+		PlotStyles: make(map[string]json.RawMessage, len(ss.plotStyles)),
+		FontSize:   ss.fontSize,
+		Disabled:   ss.disabled,
+	}
+
+	for id, col := range ss.colors {
+		result.Colors[id.String()] = [4]float32{col.X, col.Y, col.Z, col.W}
+	}
+
+	for id, col := range ss.plotColors {
+		v := plotColorToVec4(col)
+		result.PlotColors[id.String()] = [4]float32{v.X, v.Y, v.Z, v.W}
+	}
+
+	for id, v := range ss.styles {
+		data, err := marshalStyleValue(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Styles[id.String()] = data
+	}
+
+	for id, v := range ss.plotStyles {
+		data, err := marshalStyleValue(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result.PlotStyles[id.String()] = data
+	}
+
+	if ss.font != nil {
+		result.Font = ss.font.fontName
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling StyleSetter: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Values are loaded on top of the current ones.
+//
+//nolint:gocognit // no
+func (ss *StyleSetter) UnmarshalJSON(data []byte) error {
+	var input styleSetterJSON
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("unmarshaling StyleSetter: %w", err)
+	}
+
+	ss.initMaps()
+
+	for name, col := range input.Colors {
+		id, err := StyleColorIDString(name)
+		if err != nil {
+			return ErrCSSParse{What: "style color name", Value: name, Detail: err}
+		}
+
+		ss.SetColorVec4(id, imgui.Vec4{X: col[0], Y: col[1], Z: col[2], W: col[3]})
+	}
+
+	for name, col := range input.PlotColors {
+		id, err := StylePlotColorIDString(name)
+		if err != nil {
+			return ErrCSSParse{What: "plot color name", Value: name, Detail: err}
+		}
+
+		ss.SetPlotColor(id, vec4ToPlotColor(imgui.Vec4{X: col[0], Y: col[1], Z: col[2], W: col[3]}))
+	}
+
+	for name, raw := range input.Styles {
+		id, err := StyleVarIDString(name)
+		if err != nil {
+			return ErrCSSParse{What: "style variable name", Value: name, Detail: err}
+		}
+
+		if err := unmarshalStyleValue(raw, func(v float32) {
+			ss.SetStyleFloat(id, v)
+		}, func(x, y float32) {
+			ss.SetStyle(id, x, y)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for name, raw := range input.PlotStyles {
+		id, err := StylePlotVarIDString(name)
+		if err != nil {
+			return ErrCSSParse{What: "plot style variable name", Value: name, Detail: err}
+		}
+
+		if err := unmarshalStyleValue(raw, func(v float32) {
+			ss.SetPlotStyleFloat(id, v)
+		}, func(x, y float32) {
+			ss.SetPlotStyle(id, x, y)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if input.Font != "" {
+		font, err := parseFontFamily(input.Font)
+		if err != nil {
+			return err
+		}
+
+		ss.font = font
+	}
+
+	if input.FontSize != 0 {
+		ss.fontSize = input.FontSize
+	}
+
+	ss.disabled = ss.disabled || input.Disabled
+
+	return nil
+}
+
+// initMaps initializes maps of StyleSetter that was not created by Style() (e.g. by json.Unmarshal).
+func (ss *StyleSetter) initMaps() {
+	if ss.colors == nil {
+		ss.colors = make(map[StyleColorID]imgui.Vec4)
+	}
+
+	if ss.styles == nil {
+		ss.styles = make(map[StyleVarID]any)
+	}
+
+	if ss.plotColors == nil {
+		ss.plotColors = make(map[StylePlotColorID]color.Color)
+	}
+
+	if ss.plotStyles == nil {
+		ss.plotStyles = make(map[StylePlotVarID]any)
+	}
+}
+
+func marshalStyleValue(v any) (json.RawMessage, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch typed := v.(type) {
+	case imgui.Vec2:
+		data, err = json.Marshal([2]float32{typed.X, typed.Y})
+	default:
+		data, err = json.Marshal(typed)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("marshaling style value: %w", err)
+	}
+
+	return data, nil
+}
+
+func unmarshalStyleValue(raw json.RawMessage, setFloat func(v float32), setVec2 func(x, y float32)) error {
+	var vec2 [2]float32
+	if err := json.Unmarshal(raw, &vec2); err == nil {
+		setVec2(vec2[0], vec2[1])
+		return nil
+	}
+
+	var f float32
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return ErrCSSParse{What: "value (not float or vec2)", Value: string(raw), Detail: err}
+	}
+
+	setFloat(f)
+
+	return nil
+}
+
+// cssFloat formats v so that parsing it as float32 gives exactly v.
+func cssFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'g', -1, 32)
+}
+
+// cssPercent formats v (0-1) as percentage (e.g. 0.26 -> 26%).
+// The shortest representation of v is shifted textually to avoid rounding errors.
+func cssPercent(v float32) string {
+	intPart, frac, _ := strings.Cut(strconv.FormatFloat(float64(v), 'f', -1, 32), ".")
+	frac += "00"
+
+	digits := strings.TrimLeft(intPart+frac[:2], "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	if rest := strings.TrimRight(frac[2:], "0"); rest != "" {
+		digits += "." + rest
+	}
+
+	return digits + "%"
+}
+
+func cssColor(col imgui.Vec4) string {
+	return fmt.Sprintf("rgba(%s, %s, %s, %s)", cssPercent(col.X), cssPercent(col.Y), cssPercent(col.Z), cssPercent(col.W))
+}
+
+func cssStyleValue(v any) string {
+	switch typed := v.(type) {
+	case imgui.Vec2:
+		return cssFloat(typed.X) + ", " + cssFloat(typed.Y)
+	case float32:
+		return cssFloat(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// plotColorToVec4 converts plot color to non-premultiplied components.
+// Colors parsed from CSS are converted exactly, other colors with 16-bit precision.
+func plotColorToVec4(col color.Color) imgui.Vec4 {
+	if c, ok := col.(csscolorparser.Color); ok {
+		return imgui.Vec4{X: float32(c.R), Y: float32(c.G), Z: float32(c.B), W: float32(c.A)}
+	}
+
+	const mask = 0xffff
+
+	c, _ := color.NRGBA64Model.Convert(col).(color.NRGBA64)
+
+	return imgui.Vec4{X: float32(c.R) / mask, Y: float32(c.G) / mask, Z: float32(c.B) / mask, W: float32(c.A) / mask}
+}
+
+func vec4ToPlotColor(v imgui.Vec4) color.Color {
+	return csscolorparser.Color{R: float64(v.X), G: float64(v.Y), B: float64(v.Z), A: float64(v.W)}
+}
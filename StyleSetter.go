@@ -42,7 +42,7 @@ func Style() *StyleSetter {
 // Add merges two StyleSetters.
 // Add puts other "on top" of ss, meaning, "other" is applied after "ss".
 // e.g. if both StyleSetters set imgui.StyleVarAlpha, the value from "other" will be used.
-// NOTE: font value "nil" is treated as "not set" and will not be changed if declared by other.
+// NOTE: font value "nil" (and font size 0) is treated as "not set" and will not be changed if declared by other.
 // NOTE: true is preffered over false for disabled field.
 // NOTE: layout field will be reset.
 func (ss *StyleSetter) Add(other *StyleSetter) *StyleSetter {
@@ -70,6 +70,10 @@ func (ss *StyleSetter) Add(other *StyleSetter) *StyleSetter {
 		ss.font = other.font
 	}
 
+	if other.fontSize != 0 {
+		ss.fontSize = other.fontSize
+	}
+
 	if other.disabled {
 		ss.disabled = true
 	}
@@ -188,6 +192,11 @@ func (ss *StyleSetter) SetFont(font *FontInfo) *StyleSetter {
 // each font's size.
 func (ss *StyleSetter) SetFontSize(size float32) *StyleSetter {
 	Assert(size > 0, "StyleSetter", "SetFontSize", "font size must be positive")
+
+	if size <= 0 {
+		return ss
+	}
+
 	ss.fontSize = size
 
 	return ss
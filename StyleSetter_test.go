@@ -1,8 +1,10 @@
 package giu
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/AllenDang/cimgui-go/imgui"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/image/colornames"
 )
@@ -51,3 +53,96 @@ func TestStyleSetter_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestStyleSetter_Marshal(t *testing.T) {
+	style := Style().
+		SetColorVec4(StyleColorText, imgui.Vec4{X: 0.95, Y: 0.123456789, Z: 1.0 / 3, W: 0.5}).
+		SetColor(StyleColorButton, colornames.Red).
+		SetStyle(StyleVarFramePadding, 4.25, 3).
+		SetStyleFloat(StyleVarAlpha, 0.7).
+		SetPlotColor(StylePlotColorLegendText, colornames.Blue).
+		SetPlotStyleFloat(StylePlotVarPlotBorderSize, 2).
+		SetPlotStyle(StylePlotVarPlotPadding, 1, 2).
+		SetFontSize(17.5).
+		SetDisabled(true)
+
+	check := func(t *testing.T, loaded *StyleSetter) {
+		t.Helper()
+
+		assert.Equal(t, style.colors, loaded.colors, "colors differ")
+		assert.Equal(t, style.styles, loaded.styles, "styles differ")
+		assert.Equal(t, style.plotStyles, loaded.plotStyles, "plot styles differ")
+		assert.Equal(t, style.fontSize, loaded.fontSize, "font size differs")
+		assert.Equal(t, style.disabled, loaded.disabled, "disabled differs")
+
+		for id, col := range style.plotColors {
+			assert.Equal(t, ToVec4Color(col), ToVec4Color(loaded.plotColors[id]), "plot color differs")
+		}
+	}
+
+	t.Run("CSS", func(t *testing.T) {
+		loaded := Style()
+		if assert.NoError(t, loaded.UnmarshalCSS(style.MarshalCSS(MainTag)), "unexpected error") {
+			check(t, loaded)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(style)
+		if !assert.NoError(t, err, "unexpected marshaling error") {
+			return
+		}
+
+		loaded := &StyleSetter{}
+		if assert.NoError(t, json.Unmarshal(data, loaded), "unexpected unmarshaling error") {
+			check(t, loaded)
+		}
+	})
+}
+
+func TestCSSStylesheet_Marshal(t *testing.T) {
+	input := []byte(`
+:root {
+	--accent: red;
+}
+
+button.danger:hover {
+	button-color: var(--accent);
+}
+
+main {
+	alpha: 0.5;
+}
+`)
+
+	ss := CSS()
+	if !assert.NoError(t, ss.Parse(input), "unexpected parsing error") {
+		return
+	}
+
+	marshaled := ss.Marshal()
+
+	loaded := CSS()
+	if assert.NoError(t, loaded.Parse(marshaled), "marshaled stylesheet doesn't parse") {
+		assert.Equal(t, string(marshaled), string(loaded.Marshal()), "round-trip changed the stylesheet")
+		assert.Equal(t, ss.variables, loaded.variables, "variables differ")
+	}
+}
+
+func TestThemes(t *testing.T) {
+	// spot-check values of themes stored as data files
+	assert.Equal(t, imgui.Vec4{X: 0.95, Y: 0.96, Z: 0.98, W: 1.00}, DefaultTheme().colors[StyleColorText], "unexpected default text color")
+	assert.Equal(t, float32(4), DefaultTheme().GetStyleFloat(StyleVarFrameRounding), "unexpected default frame rounding")
+	assert.Equal(t, imgui.Vec4{X: 0.68, Y: 0.68, Z: 0.74, W: 1.00}, LightTheme().colors[StyleColorTableBorderLight], "unexpected light table border color")
+}
+
+func TestStyleSetter_resetTo(t *testing.T) {
+	ss := Style().SetStyleFloat(StyleVarAlpha, 0.5).SetColor(StyleColorButton, colornames.Red)
+	ss.resetTo(LightTheme())
+
+	assert.Equal(t, LightTheme().colors, ss.colors, "colors not reset")
+	assert.NotContains(t, ss.styles, StyleVarAlpha, "value missing in theme wasn't removed")
+
+	ss.SetColorVec4(StyleColorText, imgui.Vec4{})
+	assert.NotEqual(t, LightTheme().colors[StyleColorText], ss.colors[StyleColorText], "maps shared with theme")
+}
@@ -1,7 +1,6 @@
 package giu
 
 import (
-	"fmt"
 	"math"
 
 	"golang.org/x/image/colornames"
@@ -22,6 +21,8 @@ type InputTextMultilineWidget struct {
 	cb             imgui.InputTextCallback
 	scrollToBottom bool
 	onChange       func()
+
+	cssAttributes
 }
 
 // InputTextMultiline creates InputTextMultilineWidget.
@@ -45,7 +46,7 @@ func (i *InputTextMultilineWidget) Label(label string) *InputTextMultilineWidget
 
 // Labelf is formatting version of Label.
 func (i *InputTextMultilineWidget) Labelf(format string, args ...any) *InputTextMultilineWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+	return i.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID sets widget's id.
@@ -84,6 +85,18 @@ func (i *InputTextMultilineWidget) AutoScrollToBottom(b bool) *InputTextMultilin
 	return i
 }
 
+// Class adds CSS classes to the input text multiline (matched by .class selectors).
+func (i *InputTextMultilineWidget) Class(classes ...string) *InputTextMultilineWidget {
+	i.addClasses(classes)
+	return i
+}
+
+// CSSID sets CSS ID of the input text multiline (matched by #id selectors).
+func (i *InputTextMultilineWidget) CSSID(id string) *InputTextMultilineWidget {
+	i.cssID = id
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputTextMultilineWidget) Build() {
 	if imgui.InputTextMultiline(
@@ -138,7 +151,7 @@ func BulletText(text string) *BulletTextWidget {
 
 // BulletTextf is a formatting version of BulletText.
 func BulletTextf(format string, args ...any) *BulletTextWidget {
-	return BulletText(fmt.Sprintf(format, args...))
+	return BulletText(Context.PrepareStringf(format, args...))
 }
 
 // Build implements Widget interface.
@@ -171,7 +184,13 @@ type InputTextWidget struct {
 	flags      InputTextFlags
 	cb         imgui.InputTextCallback
 	onChange   func()
+	undo       *UndoStack
 	focus      bool
+
+	// bind writes value back to the bound Observable (see InputTextBound)
+	bind func()
+
+	cssAttributes
 }
 
 // InputText creates new input text widget.
@@ -196,7 +215,7 @@ func (i *InputTextWidget) Label(label string) *InputTextWidget {
 
 // Labelf adds formatted label.
 func (i *InputTextWidget) Labelf(format string, args ...any) *InputTextWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+	return i.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID sets widget's id.
@@ -242,12 +261,30 @@ func (i *InputTextWidget) OnChange(onChange func()) *InputTextWidget {
 	return i
 }
 
+// UndoStack sets stack recording changes of the input text's value (see UndoStack).
+func (i *InputTextWidget) UndoStack(stack *UndoStack) *InputTextWidget {
+	i.undo = stack
+	return i
+}
+
 // Focus sets if the field should have keyboard focus.
 func (i *InputTextWidget) Focus(focus bool) *InputTextWidget {
 	i.focus = focus
 	return i
 }
 
+// Class adds CSS classes to the input text (matched by .class selectors).
+func (i *InputTextWidget) Class(classes ...string) *InputTextWidget {
+	i.addClasses(classes)
+	return i
+}
+
+// CSSID sets CSS ID of the input text (matched by #id selectors).
+func (i *InputTextWidget) CSSID(id string) *InputTextWidget {
+	i.cssID = id
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputTextWidget) Build() {
 	// Get state
@@ -267,7 +304,13 @@ func (i *InputTextWidget) Build() {
 		imgui.SetKeyboardFocusHere()
 	}
 
+	before := *i.value
 	isChanged := imgui.InputTextWithHint(i.label.String(), i.hint, i.value, imgui.InputTextFlags(i.flags), i.cb)
+	trackEdit(i.undo, i.label, i.value, before, i.bind)
+
+	if isChanged && i.bind != nil {
+		i.bind()
+	}
 
 	if isChanged && i.onChange != nil {
 		i.onChange()
@@ -318,6 +361,10 @@ func (i *InputTextWidget) handleAutoComplete(state *inputTextState) {
 		*i.value = state.autoCompleteCandidates[state.currentIdx].Str
 		state.autoCompleteCandidates = nil
 
+		if i.bind != nil {
+			i.bind()
+		}
+
 		if i.onChange != nil {
 			i.onChange()
 		}
@@ -343,8 +390,11 @@ type InputIntWidget struct {
 	width    float32
 	flags    InputTextFlags
 	onChange func()
+	undo     *UndoStack
 	step     int
 	stepFast int
+
+	cssAttributes
 }
 
 // InputInt creates input int widget
@@ -369,7 +419,7 @@ func (i *InputIntWidget) Label(label string) *InputIntWidget {
 
 // Labelf sets formatted label.
 func (i *InputIntWidget) Labelf(format string, args ...any) *InputIntWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+	return i.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID sets widget's id.
@@ -408,6 +458,24 @@ func (i *InputIntWidget) OnChange(onChange func()) *InputIntWidget {
 	return i
 }
 
+// UndoStack sets stack recording changes of the input int's value (see UndoStack).
+func (i *InputIntWidget) UndoStack(stack *UndoStack) *InputIntWidget {
+	i.undo = stack
+	return i
+}
+
+// Class adds CSS classes to the input int (matched by .class selectors).
+func (i *InputIntWidget) Class(classes ...string) *InputIntWidget {
+	i.addClasses(classes)
+	return i
+}
+
+// CSSID sets CSS ID of the input int (matched by #id selectors).
+func (i *InputIntWidget) CSSID(id string) *InputIntWidget {
+	i.cssID = id
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputIntWidget) Build() {
 	if i.width != 0 {
@@ -416,13 +484,17 @@ func (i *InputIntWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.InputIntV(
+	before := *i.value
+	isChanged := imgui.InputIntV(
 		i.label.String(),
 		i.value,
 		int32(i.step),
 		int32(i.stepFast),
 		imgui.InputTextFlags(i.flags),
-	) && i.onChange != nil {
+	)
+	trackEdit(i.undo, i.label, i.value, before, nil)
+
+	if isChanged && i.onChange != nil {
 		i.onChange()
 	}
 }
@@ -437,8 +509,11 @@ type InputFloatWidget struct {
 	flags    InputTextFlags
 	format   string
 	onChange func()
+	undo     *UndoStack
 	step     float32
 	stepFast float32
+
+	cssAttributes
 }
 
 // InputFloat constructs InputFloatWidget.
@@ -461,7 +536,7 @@ func (i *InputFloatWidget) Label(label string) *InputFloatWidget {
 
 // Labelf sets formatted label.
 func (i *InputFloatWidget) Labelf(format string, args ...any) *InputFloatWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+	return i.Label(Context.PrepareStringf(format, args...))
 }
 
 // ID sets widget's id.
@@ -494,6 +569,12 @@ func (i *InputFloatWidget) OnChange(onChange func()) *InputFloatWidget {
 	return i
 }
 
+// UndoStack sets stack recording changes of the input float's value (see UndoStack).
+func (i *InputFloatWidget) UndoStack(stack *UndoStack) *InputFloatWidget {
+	i.undo = stack
+	return i
+}
+
 // StepSize sets the step size.
 func (i *InputFloatWidget) StepSize(step float32) *InputFloatWidget {
 	i.step = step
@@ -506,6 +587,18 @@ func (i *InputFloatWidget) StepSizeFast(stepFast float32) *InputFloatWidget {
 	return i
 }
 
+// Class adds CSS classes to the input float (matched by .class selectors).
+func (i *InputFloatWidget) Class(classes ...string) *InputFloatWidget {
+	i.addClasses(classes)
+	return i
+}
+
+// CSSID sets CSS ID of the input float (matched by #id selectors).
+func (i *InputFloatWidget) CSSID(id string) *InputFloatWidget {
+	i.cssID = id
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputFloatWidget) Build() {
 	if i.width != 0 {
@@ -514,14 +607,18 @@ func (i *InputFloatWidget) Build() {
 		defer PopItemWidth()
 	}
 
-	if imgui.InputFloatV(
+	before := *i.value
+	isChanged := imgui.InputFloatV(
 		i.label.String(),
 		i.value,
 		i.step,
 		i.stepFast,
 		i.format,
 		imgui.InputTextFlags(i.flags),
-	) && i.onChange != nil {
+	)
+	trackEdit(i.undo, i.label, i.value, before, nil)
+
+	if isChanged && i.onChange != nil {
 		i.onChange()
 	}
 }
@@ -533,6 +630,8 @@ type LabelWidget struct {
 	label    string
 	fontInfo *FontInfo
 	wrapped  bool
+
+	cssAttributes
 }
 
 // Label constructs label widget.
@@ -545,7 +644,7 @@ func Label(label string) *LabelWidget {
 
 // Labelf allows to add formatted label.
 func Labelf(format string, args ...any) *LabelWidget {
-	return Label(fmt.Sprintf(format, args...))
+	return Label(Context.PrepareStringf(format, args...))
 }
 
 // Wrapped determines if label is wrapped.
@@ -560,6 +659,18 @@ func (l *LabelWidget) Font(font *FontInfo) *LabelWidget {
 	return l
 }
 
+// Class adds CSS classes to the label (matched by .class selectors).
+func (l *LabelWidget) Class(classes ...string) *LabelWidget {
+	l.addClasses(classes)
+	return l
+}
+
+// CSSID sets CSS ID of the label (matched by #id selectors).
+func (l *LabelWidget) CSSID(id string) *LabelWidget {
+	l.cssID = id
+	return l
+}
+
 // Build implements Widget interface.
 func (l *LabelWidget) Build() {
 	if l.wrapped {
@@ -28,6 +28,11 @@ type textureFreeRequest struct {
 // NOTE: remember to call it after NewMasterWindow!
 func EnqueueNewTextureFromRgba(rgba image.Image, loadCb func(t *Texture)) {
 	Assert((Context.textureLoadingQueue != nil), "", "EnqueueNewTextureFromRgba", "you need to call EnqueueNewTextureFromRgba after giu.NewMasterWindow call!")
+
+	if Context.textureLoadingQueue == nil {
+		return
+	}
+
 	Context.textureLoadingQueue.Add(textureLoadRequest{rgba, loadCb})
 }
 
@@ -38,6 +43,8 @@ func NewTextureFromRgba(rgba image.Image, loadCallback func(*Texture)) {
 		tex,
 	}
 
+	Context.profiler.textures.Add(1)
+
 	runtime.SetFinalizer(giuTex, func(tex *Texture) {
 		Context.textureFreeingQueue.Add(textureFreeRequest{tex})
 	})
@@ -0,0 +1,535 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ErrTranslationParse is returned when a locale file cannot be parsed.
+type ErrTranslationParse struct {
+	Format string // format of the file (e.g. "po")
+	Line   int    // (optional) line number (1-based) where parsing failed
+	Value  string // the value which failed
+	Detail error  // (optional) error to add extra detail
+}
+
+func (e ErrTranslationParse) Error() string {
+	errStr := fmt.Sprintf("unable to parse %s", e.Format)
+
+	if e.Line > 0 {
+		errStr += fmt.Sprintf(" (line %d)", e.Line)
+	}
+
+	errStr += fmt.Sprintf(": %q", e.Value)
+
+	if e.Detail != nil {
+		errStr += fmt.Sprintf(" - %s", e.Detail.Error())
+	}
+
+	return errStr
+}
+
+// translationCatalog is a content of a locale file.
+type translationCatalog struct {
+	source  map[string]string
+	plurals map[string]map[PluralCategory]string
+}
+
+// translationParsers maps file extensions to parsers of locale files.
+// tag is a language of the file (plural forms of gettext catalogs depend on it).
+var translationParsers = map[string]func(tag string, data []byte) (*translationCatalog, error){
+	".po": func(tag string, data []byte) (*translationCatalog, error) {
+		entries, err := parsePO(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return poEntriesToCatalog(tag, entries), nil
+	},
+	".mo": func(tag string, data []byte) (*translationCatalog, error) {
+		entries, err := parseMO(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return poEntriesToCatalog(tag, entries), nil
+	},
+	".json": parseTranslationJSON,
+	".yaml": parseTranslationYAML,
+	".yml":  parseTranslationYAML,
+}
+
+// LoadLanguage loads file name from fsys and adds its translations to language tag
+// (existing translations of tag are kept unless the file overrides them).
+// Format is chosen by file extension:
+// - .po, .mo (gettext) - msgstr[n] of plural messages are CLDR categories of tag in order (see PluralCategories)
+// - .json or .yaml/.yml - object mapping source strings to translations;
+// plural forms are objects mapping CLDR categories to translations (e.g. {"one": "{count} file", "other": "{count} files"}).
+func (t *BasicTranslator) LoadLanguage(tag string, fsys fs.FS, name string) error {
+	tag = normalizeLanguageTag(tag)
+
+	parse, ok := translationParsers[strings.ToLower(path.Ext(name))]
+	if !ok {
+		return fmt.Errorf("loading %s: unknown locale file format", name)
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", name, err)
+	}
+
+	catalog, err := parse(tag, data)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", name, err)
+	}
+
+	if existing, ok := t.source[tag]; ok {
+		maps.Copy(existing, catalog.source)
+	} else {
+		t.AddLanguage(tag, catalog.source)
+	}
+
+	for key, forms := range catalog.plurals {
+		t.AddPlural(tag, key, forms)
+	}
+
+	return nil
+}
+
+// LoadLanguages loads all locale files from directory dir of fsys.
+// The following layouts are recognized (see LoadLanguage for supported formats):
+// - <dir>/<tag>.<ext> (e.g. locales/pt-BR.json)
+// - <dir>/<tag>/LC_MESSAGES/<domain>.<ext> (gettext layout, e.g. locales/pt_BR/LC_MESSAGES/app.po)
+// Tags are normalized (e.g. pt_BR is loaded as pt-BR).
+// Other files are ignored.
+func (t *BasicTranslator) LoadLanguages(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("loading languages: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := path.Join(dir, entry.Name())
+
+		if !entry.IsDir() {
+			ext := path.Ext(entry.Name())
+			if _, ok := translationParsers[strings.ToLower(ext)]; ok {
+				if err := t.LoadLanguage(strings.TrimSuffix(entry.Name(), ext), fsys, name); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		files, err := fs.ReadDir(fsys, path.Join(name, "LC_MESSAGES"))
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			if _, ok := translationParsers[strings.ToLower(path.Ext(file.Name()))]; ok && !file.IsDir() {
+				if err := t.LoadLanguage(entry.Name(), fsys, path.Join(name, "LC_MESSAGES", file.Name())); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// poEntry is a single message of gettext catalog.
+type poEntry struct {
+	context  string
+	id       string
+	idPlural string
+	// str is msgstr (or msgstr[n] for plural messages)
+	str   []string
+	fuzzy bool
+}
+
+// key returns dictionary key of the message.
+// Messages with context are keyed "context\x04id" as in gettext.
+func (e *poEntry) key() string {
+	if e.context != "" {
+		return e.context + "\x04" + e.id
+	}
+
+	return e.id
+}
+
+// poEntriesToSource converts entries to a dictionary.
+// The header, fuzzy and untranslated messages are skipped; plural messages use their first form.
+func poEntriesToSource(entries []*poEntry) map[string]string {
+	result := make(map[string]string, len(entries))
+
+	for _, e := range entries {
+		if e.id == "" || e.fuzzy || len(e.str) == 0 || e.str[0] == "" {
+			continue
+		}
+
+		result[e.key()] = e.str[0]
+	}
+
+	return result
+}
+
+// poEntriesToCatalog is like poEntriesToSource, but also maps plural forms to categories of language tag.
+func poEntriesToCatalog(tag string, entries []*poEntry) *translationCatalog {
+	result := &translationCatalog{
+		source:  poEntriesToSource(entries),
+		plurals: make(map[string]map[PluralCategory]string),
+	}
+
+	categories := PluralCategories(tag)
+
+	for _, e := range entries {
+		if e.id == "" || e.fuzzy || e.idPlural == "" {
+			continue
+		}
+
+		forms := make(map[PluralCategory]string)
+
+		for i, str := range e.str {
+			if i < len(categories) && str != "" {
+				forms[categories[i]] = str
+			}
+		}
+
+		if len(forms) > 0 {
+			result.plurals[e.key()] = forms
+		}
+	}
+
+	return result
+}
+
+// ParsePO parses gettext .po file into a dictionary suitable for BasicTranslator.AddLanguage.
+// Fuzzy and untranslated messages are skipped.
+func ParsePO(data []byte) (map[string]string, error) {
+	entries, err := parsePO(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return poEntriesToSource(entries), nil
+}
+
+//nolint:gocognit,gocyclo // it is a state machine
+func parsePO(data []byte) ([]*poEntry, error) {
+	var (
+		entries []*poEntry
+		current *poEntry
+		// target is a field continued by string-only lines
+		target *string
+		fuzzy  bool
+	)
+
+	// begin starts a new entry unless the current one has no msgstr yet (e.g. msgctxt followed by msgid)
+	begin := func() {
+		if current != nil && len(current.str) == 0 {
+			return
+		}
+
+		current = &poEntry{fuzzy: fuzzy}
+		entries = append(entries, current)
+		fuzzy = false
+	}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "", strings.HasPrefix(line, "#~"):
+			// blank line or obsolete message
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if strings.Contains(line, "fuzzy") {
+				fuzzy = true
+			}
+
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if target == nil {
+				return nil, ErrTranslationParse{Format: "po", Line: lineNo, Value: line, Detail: errors.New("string without keyword")}
+			}
+
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, ErrTranslationParse{Format: "po", Line: lineNo, Value: line, Detail: err}
+			}
+
+			*target += value
+
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+
+		value, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, ErrTranslationParse{Format: "po", Line: lineNo, Value: line, Detail: err}
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			begin()
+			current.context = value
+			target = &current.context
+		case keyword == "msgid":
+			begin()
+			current.id = value
+			target = &current.id
+		case keyword == "msgid_plural" && current != nil:
+			current.idPlural = value
+			target = &current.idPlural
+		case keyword == "msgstr" && current != nil && len(current.str) == 0:
+			current.str = append(current.str, value)
+			target = &current.str[0]
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]") && current != nil:
+			n, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || n != len(current.str) {
+				return nil, ErrTranslationParse{Format: "po", Line: lineNo, Value: line, Detail: errors.New("unexpected plural form index")}
+			}
+
+			current.str = append(current.str, value)
+			target = &current.str[n]
+		default:
+			return nil, ErrTranslationParse{Format: "po", Line: lineNo, Value: line, Detail: fmt.Errorf("unexpected keyword %s", keyword)}
+		}
+	}
+
+	return entries, nil
+}
+
+// ParseMO parses gettext .mo (compiled) file into a dictionary suitable for BasicTranslator.AddLanguage.
+func ParseMO(data []byte) (map[string]string, error) {
+	entries, err := parseMO(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return poEntriesToSource(entries), nil
+}
+
+const (
+	moMagic      uint32 = 0x950412de
+	moHeaderSize        = 28
+)
+
+func parseMO(data []byte) ([]*poEntry, error) {
+	if len(data) < moHeaderSize {
+		return nil, ErrTranslationParse{Format: "mo", Value: "header", Detail: errors.New("file too short")}
+	}
+
+	var order binary.ByteOrder
+
+	switch moMagic {
+	case binary.LittleEndian.Uint32(data):
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data):
+		order = binary.BigEndian
+	default:
+		return nil, ErrTranslationParse{Format: "mo", Value: "magic number", Detail: errors.New("not a .mo file")}
+	}
+
+	if revision := order.Uint32(data[4:]); revision>>16 > 1 {
+		return nil, ErrTranslationParse{Format: "mo", Value: "revision", Detail: fmt.Errorf("unsupported revision %d", revision)}
+	}
+
+	count := uint64(order.Uint32(data[8:]))
+	originals := uint64(order.Uint32(data[12:]))
+	translations := uint64(order.Uint32(data[16:]))
+
+	// readString reads i-th string of the table at offset
+	readString := func(table, i uint64) (string, error) {
+		descriptor := table + i*8
+		if descriptor+8 > uint64(len(data)) {
+			return "", ErrTranslationParse{Format: "mo", Value: "string table", Detail: fmt.Errorf("offset %d out of range", descriptor)}
+		}
+
+		length := uint64(order.Uint32(data[descriptor:]))
+		offset := uint64(order.Uint32(data[descriptor+4:]))
+
+		if offset+length > uint64(len(data)) {
+			return "", ErrTranslationParse{Format: "mo", Value: "string", Detail: fmt.Errorf("offset %d out of range", offset)}
+		}
+
+		return string(data[offset : offset+length]), nil
+	}
+
+	entries := make([]*poEntry, 0, count)
+
+	for i := range count {
+		original, err := readString(originals, i)
+		if err != nil {
+			return nil, err
+		}
+
+		translation, err := readString(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &poEntry{str: strings.Split(translation, "\x00")}
+
+		if context, id, ok := strings.Cut(original, "\x04"); ok {
+			entry.context, original = context, id
+		}
+
+		entry.id, entry.idPlural, _ = strings.Cut(original, "\x00")
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseTranslationJSON(_ string, data []byte) (*translationCatalog, error) {
+	var input map[string]any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, ErrTranslationParse{Format: "json", Value: "locale file", Detail: err}
+	}
+
+	return mapToCatalog("json", input)
+}
+
+func parseTranslationYAML(_ string, data []byte) (*translationCatalog, error) {
+	var input map[string]any
+	if err := yaml.Unmarshal(data, &input); err != nil {
+		return nil, ErrTranslationParse{Format: "yaml", Value: "locale file", Detail: err}
+	}
+
+	return mapToCatalog("yaml", input)
+}
+
+// mapToCatalog converts decoded JSON/YAML locale file.
+// Values are either strings or objects mapping plural categories to strings.
+func mapToCatalog(format string, input map[string]any) (*translationCatalog, error) {
+	result := &translationCatalog{
+		source:  make(map[string]string),
+		plurals: make(map[string]map[PluralCategory]string),
+	}
+
+	for key, value := range input {
+		switch typed := value.(type) {
+		case string:
+			result.source[key] = typed
+		case map[string]any:
+			forms := make(map[PluralCategory]string, len(typed))
+
+			for name, form := range typed {
+				category, err := ParsePluralCategory(name)
+				if err != nil {
+					return nil, ErrTranslationParse{Format: format, Value: key, Detail: err}
+				}
+
+				str, ok := form.(string)
+				if !ok {
+					return nil, ErrTranslationParse{Format: format, Value: key, Detail: fmt.Errorf("plural form %s is not a string", name)}
+				}
+
+				forms[category] = str
+			}
+
+			result.plurals[key] = forms
+		default:
+			return nil, ErrTranslationParse{Format: format, Value: key, Detail: errors.New("value is neither string nor object of plural forms")}
+		}
+	}
+
+	return result, nil
+}
+
+// writeTranslationTemplate writes locale file of language tag with empty translations of keys
+// (the value tells whether the key needs plural forms).
+// format is one of "po", "json" or "yaml".
+func writeTranslationTemplate(w io.Writer, format, tag string, keys map[string]bool) error {
+	categories := PluralCategories(tag)
+
+	var data []byte
+
+	switch format {
+	case "po":
+		var buf bytes.Buffer
+
+		fmt.Fprintf(&buf, "msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n\"Language: %s\\n\"\n", tag)
+
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			buf.WriteString("\n")
+
+			id := key
+			if context, rest, ok := strings.Cut(key, "\x04"); ok {
+				fmt.Fprintf(&buf, "msgctxt %s\n", strconv.Quote(context))
+				id = rest
+			}
+
+			fmt.Fprintf(&buf, "msgid %s\n", strconv.Quote(id))
+
+			if !keys[key] {
+				buf.WriteString("msgstr \"\"\n")
+				continue
+			}
+
+			fmt.Fprintf(&buf, "msgid_plural %s\n", strconv.Quote(id))
+
+			for i := range categories {
+				fmt.Fprintf(&buf, "msgstr[%d] \"\"\n", i)
+			}
+		}
+
+		data = buf.Bytes()
+	case "json", "yaml":
+		template := make(map[string]any, len(keys))
+
+		for key, plural := range keys {
+			if !plural {
+				template[key] = ""
+				continue
+			}
+
+			forms := make(map[string]string, len(categories))
+			for _, c := range categories {
+				forms[string(c)] = ""
+			}
+
+			template[key] = forms
+		}
+
+		var err error
+		if format == "json" {
+			data, err = json.MarshalIndent(template, "", "\t")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(template)
+		}
+
+		if err != nil {
+			return fmt.Errorf("writing translation template: %w", err)
+		}
+	default:
+		return fmt.Errorf("writing translation template: unknown format %q", format)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing translation template: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,10 @@
 package giu
 
 import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -19,10 +23,36 @@ type Translator interface {
 // See also Context.RegisterString.
 func (c *GIUContext) SetTranslator(t Translator) {
 	Assert(t != nil, "Context", "SetTranslator", "Translator must not be nil.")
+
+	if t == nil {
+		return
+	}
+
 	c.Translator = t
 }
 
-var _ Translator = &EmptyTranslator{}
+// PluralTranslator is a Translator which also supports plural forms.
+// If Context's Translator implements it, GIUContext.TrN uses it.
+type PluralTranslator interface {
+	Translator
+	// TranslateN returns translation of key in plural form suitable for n.
+	// {count} placeholder in the result should be replaced with n.
+	TranslateN(key string, n int) string
+}
+
+// ContextTranslator is a Translator which also supports messages with context
+// (e.g. gettext's msgctxt) to translate the same key differently depending on where it is used.
+// If Context's Translator implements it, GIUContext.TrC uses it.
+type ContextTranslator interface {
+	Translator
+	// TranslateContext returns translation of key in context.
+	TranslateContext(context, key string) string
+}
+
+var (
+	_ PluralTranslator  = &EmptyTranslator{}
+	_ ContextTranslator = &EmptyTranslator{}
+)
 
 // EmptyTranslator is the default one (to save resources).
 // It does nothing.
@@ -33,12 +63,26 @@ func (t *EmptyTranslator) Translate(s string) string {
 	return s
 }
 
+// TranslateN implements PluralTranslator interface.
+// It only replaces {count} placeholder.
+func (t *EmptyTranslator) TranslateN(key string, n int) string {
+	return ReplacePlaceholders(key, map[string]any{"count": n})
+}
+
+// TranslateContext implements ContextTranslator interface.
+func (t *EmptyTranslator) TranslateContext(_, key string) string {
+	return key
+}
+
 // SetLanguage implements Translator interface.
 func (t *EmptyTranslator) SetLanguage(_ string) error {
 	return nil
 }
 
-var _ Translator = &BasicTranslator{}
+var (
+	_ PluralTranslator  = &BasicTranslator{}
+	_ ContextTranslator = &BasicTranslator{}
+)
 
 // BasicTranslator is a simpliest implementation of translation mechanism.
 // This is NOT thread-safe yet. If you need thread-safety, write your own implementation.
@@ -49,10 +93,21 @@ var _ Translator = &BasicTranslator{}
 // - set translator in Context
 // - set default language
 // - write your UI as always.
+//
+// Languages may be loaded from files (see LoadLanguage and LoadLanguages).
+// Language tags are normalized, so "pt_BR" (gettext locale) and "pt-BR" (BCP 47 tag) are the same language.
+// When a key is missing in the current language, it is looked up in its fallback chain (see SetFallback).
 type BasicTranslator struct {
 	// language tag -> key -> value
 	source          map[string]map[string]string
 	currentLanguage string
+	// language tag -> tags tried when a key is missing (see SetFallback)
+	fallbacks       map[string][]string
+	defaultLanguage string
+	// language tag -> key -> plural forms (see AddPlural)
+	plurals map[string]map[string]map[PluralCategory]string
+	// language tag -> missing key -> whether it was requested as plural
+	missing map[string]map[string]bool
 }
 
 // NewBasicTranslator creates a new BasicTranslator with the given language tag.
@@ -64,29 +119,124 @@ func NewBasicTranslator() *BasicTranslator {
 // It translates s to the current language, under the following conditions:
 // - If s is empty, an empty string is returned with no further processing.
 // - If t.currentLanguage is empty, a panic will be raised.
-// - If t.currentLanguage is not in a.source, BasicTranslator raises panic.
-// - If s is not in source[currentLanguage], s is returned as-is.
+// - If neither t.currentLanguage nor any language of its fallback chain is in t.source, BasicTranslator raises panic.
+// - If s is not in source of any language in the chain, s is returned as-is.
+// If s is missing in the current language, it is recorded (see MissingKeys).
 func (t *BasicTranslator) Translate(s string) string {
 	s = strings.Split(s, "##")[0]
 	if s == "" {
 		return ""
 	}
 
-	Assert(t.currentLanguage != "", "BasicTranslator", "Translate", "Current language is not set, so there is no sense in using BasicTranslator.")
-	locale, ok := t.source[t.currentLanguage]
-	Assert(ok, "BasicTranslator", "Translate", "There is no language tag %s known by the translator. Did you add it?", t.currentLanguage)
+	return t.lookup("Translate", s, 0, false)
+}
 
-	translated, ok := locale[s]
-	if !ok {
-		return s
+// TranslateN implements PluralTranslator interface.
+// It works like Translate, but uses plural form of key (see AddPlural) for the CLDR category of n
+// (falling back to "other" form and then to the non-plural translation).
+// {count} placeholder is replaced with n.
+func (t *BasicTranslator) TranslateN(key string, n int) string {
+	key = strings.Split(key, "##")[0]
+	if key == "" {
+		return ""
+	}
+
+	return ReplacePlaceholders(t.lookup("TranslateN", key, n, true), map[string]any{"count": n})
+}
+
+// TranslateContext implements ContextTranslator interface.
+// It works like Translate, but looks up key with context (msgctxt of gettext catalogs).
+// If there is no such a message, key is returned as-is.
+func (t *BasicTranslator) TranslateContext(context, key string) string {
+	key = strings.Split(key, "##")[0]
+	if key == "" {
+		return ""
+	}
+
+	contextKey := context + "\x04" + key
+	if translated := t.lookup("TranslateContext", contextKey, 0, false); translated != contextKey {
+		return translated
+	}
+
+	return key
+}
+
+// lookup searches key in the fallback chain of the current language.
+func (t *BasicTranslator) lookup(method, key string, n int, plural bool) string {
+	Assert(t.currentLanguage != "", "BasicTranslator", method, "Current language is not set, so there is no sense in using BasicTranslator.")
+
+	if t.currentLanguage == "" {
+		return key
+	}
+
+	known := false
+
+	for i, tag := range t.FallbackChain(t.currentLanguage) {
+		locale, hasSource := t.source[tag]
+		plurals, hasPlurals := t.plurals[tag]
+
+		if !hasSource && !hasPlurals {
+			continue
+		}
+
+		known = true
+
+		translated, ok := "", false
+
+		if forms, isPlural := plurals[key]; plural && isPlural {
+			if translated, ok = forms[PluralCategoryOf(tag, n)]; !ok {
+				translated, ok = forms[PluralOther]
+			}
+		}
+
+		if !ok {
+			translated, ok = locale[key]
+		}
+
+		if ok {
+			if i > 0 {
+				t.recordMissing(key, plural)
+			}
+
+			return translated
+		}
+	}
+
+	Assert(known, "BasicTranslator", method, "There is no language tag %s known by the translator. Did you add it?", t.currentLanguage)
+
+	t.recordMissing(key, plural)
+
+	return key
+}
+
+func (t *BasicTranslator) recordMissing(key string, plural bool) {
+	if t.missing == nil {
+		t.missing = make(map[string]map[string]bool)
 	}
 
-	return translated
+	if t.missing[t.currentLanguage] == nil {
+		t.missing[t.currentLanguage] = make(map[string]bool)
+	}
+
+	t.missing[t.currentLanguage][key] = t.missing[t.currentLanguage][key] || plural
+}
+
+// MissingKeys returns (sorted) keys which were requested, but are missing in the current language
+// (even if they were found in its fallback chain).
+func (t *BasicTranslator) MissingKeys() []string {
+	return slices.Sorted(maps.Keys(t.missing[t.currentLanguage]))
+}
+
+// ExportMissing writes keys returned by MissingKeys as a template of locale file
+// which can be filled in and loaded by LoadLanguage.
+// format is one of "po", "json" or "yaml". Keys requested by TranslateN get all plural forms of the current language.
+func (t *BasicTranslator) ExportMissing(w io.Writer, format string) error {
+	return writeTranslationTemplate(w, format, t.currentLanguage, t.missing[t.currentLanguage])
 }
 
 // SetLanguage sets the current language of the translator.
 func (t *BasicTranslator) SetLanguage(tag string) error {
-	t.currentLanguage = tag
+	t.currentLanguage = normalizeLanguageTag(tag)
 	return nil
 }
 
@@ -96,7 +246,135 @@ func (t *BasicTranslator) AddLanguage(tag string, source map[string]string) *Bas
 		t.source = make(map[string]map[string]string)
 	}
 
-	t.source[tag] = source
+	t.source[normalizeLanguageTag(tag)] = source
 
 	return t
 }
+
+// AddPlural adds plural forms of key (e.g. "{count} files") in language tag.
+// forms should contain all categories used by the language (see PluralCategories).
+func (t *BasicTranslator) AddPlural(tag, key string, forms map[PluralCategory]string) *BasicTranslator {
+	if t.plurals == nil {
+		t.plurals = make(map[string]map[string]map[PluralCategory]string)
+	}
+
+	tag = normalizeLanguageTag(tag)
+
+	if t.plurals[tag] == nil {
+		t.plurals[tag] = make(map[string]map[PluralCategory]string)
+	}
+
+	t.plurals[tag][key] = forms
+
+	return t
+}
+
+// SetFallback sets languages looked up (in order) when a key is missing in the language tag.
+// By default, tag falls back to its parents (e.g. "pt-BR" -> "pt") and then to the default language.
+func (t *BasicTranslator) SetFallback(tag string, fallbacks ...string) *BasicTranslator {
+	if t.fallbacks == nil {
+		t.fallbacks = make(map[string][]string)
+	}
+
+	normalized := make([]string, len(fallbacks))
+	for i, fallback := range fallbacks {
+		normalized[i] = normalizeLanguageTag(fallback)
+	}
+
+	t.fallbacks[normalizeLanguageTag(tag)] = normalized
+
+	return t
+}
+
+// DefaultLanguage sets language which ends every fallback chain (e.g. language the UI is written in).
+func (t *BasicTranslator) DefaultLanguage(tag string) *BasicTranslator {
+	t.defaultLanguage = normalizeLanguageTag(tag)
+	return t
+}
+
+// FallbackChain returns language tags searched by Translate when language tag is set.
+// It is tag, its fallbacks (see SetFallback) and the default language.
+func (t *BasicTranslator) FallbackChain(tag string) []string {
+	tag = normalizeLanguageTag(tag)
+	chain := []string{tag}
+
+	fallbacks, ok := t.fallbacks[tag]
+	if !ok {
+		// pt-BR -> pt
+		for parent := tag; ; {
+			i := strings.LastIndex(parent, "-")
+			if i <= 0 {
+				break
+			}
+
+			parent = parent[:i]
+			fallbacks = append(fallbacks, parent)
+		}
+	}
+
+	chain = append(chain, fallbacks...)
+
+	if t.defaultLanguage != "" {
+		chain = append(chain, t.defaultLanguage)
+	}
+
+	// remove duplicates keeping the first occurrence
+	result := make([]string, 0, len(chain))
+
+	for _, tag := range chain {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
+// normalizeLanguageTag converts gettext locale name (e.g. "pt_BR.UTF-8") to language tag ("pt-BR").
+func normalizeLanguageTag(tag string) string {
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	return strings.ReplaceAll(tag, "_", "-")
+}
+
+// ReplacePlaceholders replaces named placeholders (e.g. {count}) in s with values of params
+// formatted by fmt.Sprint. Placeholders missing in params are kept as-is.
+func ReplacePlaceholders(s string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(s, "{") {
+		return s
+	}
+
+	var result strings.Builder
+
+	for {
+		start := strings.Index(s, "{")
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+
+		end += start
+
+		value, ok := params[s[start+1:end]]
+		if !ok {
+			result.WriteString(s[:start+1])
+			s = s[start+1:]
+
+			continue
+		}
+
+		result.WriteString(s[:start])
+		result.WriteString(fmt.Sprint(value))
+		s = s[end+1:]
+	}
+
+	result.WriteString(s)
+
+	return result.String()
+}
@@ -0,0 +1,252 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePO(t *testing.T) {
+	source, err := ParsePO([]byte(`
+# header
+msgid ""
+msgstr ""
+"Language: pl\n"
+
+#: main.go:12
+msgid "Hello world!"
+msgstr "Witaj "
+"świecie"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Otwórz"
+
+#, fuzzy
+msgid "Save"
+msgstr "Zapisz"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "file"
+msgid_plural "files"
+msgstr[0] "plik"
+msgstr[1] "pliki"
+
+#~ msgid "Obsolete"
+#~ msgstr "Przestarzały"
+`))
+
+	if !assert.NoError(t, err, "unexpected parsing error") {
+		return
+	}
+
+	assert.Equal(t, map[string]string{
+		"Hello world!": "Witaj świecie",
+		"menu\x04Open": "Otwórz",
+		"file":         "plik",
+	}, source, "unexpected dictionary")
+
+	_, err = ParsePO([]byte(`msgid "a"` + "\n" + `msgstr[1] "b"`))
+	assert.ErrorAs(t, err, &ErrTranslationParse{}, "plural form out of order accepted")
+}
+
+// encodeMO writes a minimal little-endian .mo file.
+func encodeMO(messages [][2]string) []byte {
+	const headerSize = 28
+
+	var header, tables, strs bytes.Buffer
+
+	stringsOffset := uint32(headerSize + len(messages)*16)
+
+	for table := range 2 {
+		for _, m := range messages {
+			_ = binary.Write(&tables, binary.LittleEndian, [2]uint32{uint32(len(m[table])), stringsOffset + uint32(strs.Len())})
+			strs.WriteString(m[table] + "\x00")
+		}
+	}
+
+	_ = binary.Write(&header, binary.LittleEndian, [7]uint32{moMagic, 0, uint32(len(messages)), headerSize, headerSize + uint32(len(messages))*8, 0, 0})
+
+	return append(append(header.Bytes(), tables.Bytes()...), strs.Bytes()...)
+}
+
+func TestParseMO(t *testing.T) {
+	source, err := ParseMO(encodeMO([][2]string{
+		{"", "Language: pl\n"},
+		{"Hello world!", "Witaj świecie"},
+		{"menu\x04Open", "Otwórz"},
+		{"file\x00files", "plik\x00pliki"},
+	}))
+
+	if assert.NoError(t, err, "unexpected parsing error") {
+		assert.Equal(t, map[string]string{
+			"Hello world!": "Witaj świecie",
+			"menu\x04Open": "Otwórz",
+			"file":         "plik",
+		}, source, "unexpected dictionary")
+	}
+
+	_, err = ParseMO([]byte("not a mo file at all, but long enough"))
+	assert.ErrorAs(t, err, &ErrTranslationParse{}, "invalid file accepted")
+}
+
+func TestBasicTranslator_LoadLanguages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":                  {Data: []byte(`{"Cancel": "Cancel!"}`)},
+		"locales/pt.yaml":                  {Data: []byte("Hello: Olá\nSave: Salvar\n")},
+		"locales/pt-BR/LC_MESSAGES/app.po": {Data: []byte("msgid \"Hello\"\nmsgstr \"Oi\"\n")},
+		"locales/README.md":                {Data: []byte("ignored")},
+	}
+
+	translator := NewBasicTranslator().DefaultLanguage("en")
+	if !assert.NoError(t, translator.LoadLanguages(fsys, "locales"), "unexpected loading error") {
+		return
+	}
+
+	assert.Equal(t, []string{"pt-BR", "pt", "en"}, translator.FallbackChain("pt-BR"), "unexpected fallback chain")
+
+	assert.NoError(t, translator.SetLanguage("pt-BR"), "setting language")
+	assert.Equal(t, "Oi", translator.Translate("Hello##id"), "key from the current language")
+	assert.Equal(t, "Salvar", translator.Translate("Save"), "key from parent language")
+	assert.Equal(t, "Cancel!", translator.Translate("Cancel"), "key from default language")
+	assert.Equal(t, "Missing", translator.Translate("Missing"), "missing key should be returned as-is")
+
+	translator.SetFallback("pt-BR", "en")
+	assert.Equal(t, "Save", translator.Translate("Save"), "explicit fallback chain ignored")
+
+	assert.Error(t, translator.LoadLanguage("de", fsys, "locales/README.md"), "unknown format accepted")
+}
+
+func TestBasicTranslator_TranslateContext(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/pt_BR/LC_MESSAGES/app.po": {Data: []byte("msgid \"Open\"\nmsgstr \"Abrir\"\n\nmsgctxt \"door\"\nmsgid \"Open\"\nmsgstr \"Aberta\"\n")},
+	}
+
+	translator := NewBasicTranslator()
+	if !assert.NoError(t, translator.LoadLanguages(fsys, "locales"), "unexpected loading error") {
+		return
+	}
+
+	assert.NoError(t, translator.SetLanguage("pt-BR"), "setting language")
+	assert.Equal(t, "Abrir", translator.Translate("Open"), "gettext locale name not normalized")
+	assert.Equal(t, "Aberta", translator.TranslateContext("door", "Open##id"), "message with context not found")
+	assert.Equal(t, "Open", translator.TranslateContext("menu", "Open"), "missing message with context should be returned as-is")
+
+	assert.NoError(t, translator.SetLanguage("pt_BR.UTF-8"), "setting language")
+	assert.Equal(t, []string{"pt-BR", "pt"}, translator.FallbackChain("pt_BR"), "unexpected fallback chain")
+
+	ctx := &GIUContext{Translator: translator}
+	assert.Equal(t, "Aberta##door", ctx.TrC("door", "Open##door", nil), "context not passed by TrC")
+	assert.Equal(t, "Open", (&GIUContext{Translator: &EmptyTranslator{}}).TrC("door", "Open", nil), "unexpected TrC of EmptyTranslator")
+}
+
+func TestPluralCategoryOf(t *testing.T) {
+	cases := []struct {
+		tag      string
+		n        int
+		expected PluralCategory
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"fr", 0, PluralOne},
+		{"pl", 1, PluralOne},
+		{"pl", 22, PluralFew},
+		{"pl", 12, PluralMany},
+		{"pl", 25, PluralMany},
+		{"ru-RU", 21, PluralOne},
+		{"ru_RU", 11, PluralMany},
+		{"cs", 3, PluralFew},
+		{"ar", 102, PluralOther},
+		{"ar", 111, PluralMany},
+		{"ja", 1, PluralOther},
+		{"xx", 1, PluralOther},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, PluralCategoryOf(tc.tag, tc.n), "unexpected category of %d in %s", tc.n, tc.tag)
+	}
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	params := map[string]any{"count": 3, "name": "a.txt"}
+
+	assert.Equal(t, "3 files", ReplacePlaceholders("{count} files", params), "placeholder not replaced")
+	assert.Equal(t, "{missing} {a.txt}", ReplacePlaceholders("{missing} {{name}}", params), "unexpected handling of unknown placeholders")
+	assert.Equal(t, "{count", ReplacePlaceholders("{count", params), "unclosed placeholder changed")
+}
+
+func TestBasicTranslator_TranslateN(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{"{count} files": {"one": "{count} file", "other": "{count} files"}}`)},
+		"pl.po": {Data: []byte(`
+msgid "{count} files"
+msgid_plural "{count} files"
+msgstr[0] "{count} plik"
+msgstr[1] "{count} pliki"
+msgstr[2] "{count} plików"
+`)},
+	}
+
+	translator := NewBasicTranslator().DefaultLanguage("en")
+	assert.NoError(t, translator.LoadLanguages(fsys, "."), "unexpected loading error")
+
+	assert.NoError(t, translator.SetLanguage("pl"), "setting language")
+	assert.Equal(t, "1 plik", translator.TranslateN("{count} files", 1), "unexpected form")
+	assert.Equal(t, "22 pliki", translator.TranslateN("{count} files", 22), "unexpected form")
+	assert.Equal(t, "5 plików", translator.TranslateN("{count} files##list", 5), "unexpected form")
+
+	assert.NoError(t, translator.SetLanguage("de"), "setting language")
+	assert.Equal(t, "1 file", translator.TranslateN("{count} files", 1), "default language not used")
+	assert.Equal(t, "Save", translator.Translate("Save"), "missing key should be returned as-is")
+	assert.Equal(t, []string{"Save", "{count} files"}, translator.MissingKeys(), "missing keys not recorded")
+
+	var template bytes.Buffer
+	if !assert.NoError(t, translator.ExportMissing(&template, "po"), "exporting template") {
+		return
+	}
+
+	entries, err := parsePO(template.Bytes())
+	if assert.NoError(t, err, "template doesn't parse") && assert.Len(t, entries, 3, "unexpected entries") {
+		assert.Equal(t, "{count} files", entries[2].idPlural, "plural key not exported as plural")
+		assert.Len(t, entries[2].str, 2, "unexpected number of plural forms for de")
+	}
+
+	template.Reset()
+
+	if assert.NoError(t, translator.ExportMissing(&template, "json"), "exporting template") {
+		assert.JSONEq(t, `{"Save": "", "{count} files": {"one": "", "other": ""}}`, template.String(), "unexpected JSON template")
+	}
+}
+
+func TestGIUContext_PrepareStringf(t *testing.T) {
+	translator := NewBasicTranslator().AddLanguage("pl", map[string]string{
+		"%d files": "%d plików",
+		"Hello":    "Cześć",
+		"Greet":    "Hello",
+	})
+	assert.NoError(t, translator.SetLanguage("pl"), "setting language")
+
+	ctx := &GIUContext{Translator: translator}
+
+	result := ctx.PrepareStringf("%d files##counter", 5)
+	assert.Equal(t, "5 plików##counter", result, "format not translated")
+	assert.Equal(t, result, ctx.PrepareString(result), "prepared string translated again")
+	assert.Equal(t, "Cześć", ctx.PrepareString("Hello"), "unexpected translation")
+
+	greeting := ctx.Tr("Greet", nil)
+	assert.Equal(t, "Hello", ctx.PrepareString(greeting), "translated string translated again")
+	assert.Equal(t, "Cześć", ctx.PrepareString("Hello"), "equal string from elsewhere should be translated")
+
+	assert.Equal(t, "5 files", (*GIUContext)(nil).PrepareStringf("%d files", 5), "nil context should format only")
+	assert.Equal(t, "3 files", ctx.TrN("{count} files", 3, nil), "unexpected plural")
+	assert.Equal(t, "Hello, Bob", ctx.Tr("Hello, {name}", map[string]any{"name": "Bob"}), "placeholder not replaced")
+	assert.Empty(t, slices.DeleteFunc(translator.MissingKeys(), func(k string) bool {
+		return k == "{count} files" || k == "Hello, {name}"
+	}), "formatted strings reported as missing")
+}
@@ -0,0 +1,440 @@
+package giu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/image/colornames"
+)
+
+// UIWatchInterval is an interval in which files of watched UI definitions are checked for changes (see UIDefinition.Watch).
+var UIWatchInterval = 500 * time.Millisecond
+
+// ErrUIDefinition is returned when UI definition can't be parsed
+// or when it refers to an unknown element, attribute or binding.
+type ErrUIDefinition struct {
+	Element string // path of the element (e.g. "Window/Row/Button"); empty if the document can't be parsed
+	Line    int    // (optional) line number (1-based) of the element
+	Value   string // the value which failed
+	Detail  error  // (optional) error to add extra detail
+}
+
+func (e ErrUIDefinition) Error() string {
+	errStr := "invalid UI definition"
+
+	if e.Element != "" {
+		errStr += fmt.Sprintf(" in %s", e.Element)
+	}
+
+	if e.Line > 0 {
+		errStr += fmt.Sprintf(" (line %d)", e.Line)
+	}
+
+	errStr += fmt.Sprintf(": %q", e.Value)
+
+	if e.Detail != nil {
+		errStr += fmt.Sprintf(" - %s", e.Detail.Error())
+	}
+
+	return errStr
+}
+
+// UINode is an element of UI definition document.
+//
+// In XML, element name is Kind and attributes are Attrs
+// (text content is stored as "text" attribute), e.g.:
+//
+//	<Row><Label>Name:</Label><InputText value="name"/></Row>
+//
+// In JSON and YAML, element is an object with "type" (Kind) and "children" keys,
+// all other keys are attributes (lists of scalars are joined with commas), e.g.:
+//
+//	{"type": "Row", "children": [{"type": "Label", "text": "Name:"}, {"type": "InputText", "value": "name"}]}
+//
+// A list of elements on the top level is the same as a Layout element.
+type UINode struct {
+	Kind     string
+	Attrs    map[string]string
+	Children []*UINode
+	Line     int
+}
+
+// ParseUINode parses UI definition document. format is one of "xml", "json" or "yaml".
+func ParseUINode(data []byte, format string) (*UINode, error) {
+	switch format {
+	case "xml":
+		return parseUIXML(data)
+	case "json", "yaml", "yml":
+		// NOTE: JSON is valid YAML, so yaml.Node gives line numbers for both.
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, ErrUIDefinition{Value: format, Detail: err}
+		}
+
+		if len(doc.Content) == 0 {
+			return nil, ErrUIDefinition{Value: format, Detail: errors.New("empty document")}
+		}
+
+		return uiNodeFromYAML(doc.Content[0])
+	default:
+		return nil, ErrUIDefinition{Value: format, Detail: errors.New("unknown format")}
+	}
+}
+
+func parseUIXML(data []byte) (*UINode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		root  *UINode
+		stack []*UINode
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line, _ := decoder.InputPos()
+
+		if err != nil {
+			return nil, ErrUIDefinition{Line: line, Value: "xml", Detail: err}
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &UINode{
+				Kind:  t.Name.Local,
+				Attrs: make(map[string]string, len(t.Attr)),
+				Line:  line,
+			}
+
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			case root != nil:
+				return nil, ErrUIDefinition{Line: line, Value: t.Name.Local, Detail: errors.New("more than one root element")}
+			default:
+				root = node
+			}
+
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" || len(stack) == 0 {
+				continue
+			}
+
+			if node := stack[len(stack)-1]; node.Attrs["text"] == "" {
+				node.Attrs["text"] = text
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, ErrUIDefinition{Value: "xml", Detail: errors.New("empty document")}
+	}
+
+	return root, nil
+}
+
+func uiNodeFromYAML(n *yaml.Node) (*UINode, error) {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		result := &UINode{Kind: "Layout", Attrs: map[string]string{}, Line: n.Line}
+
+		for _, child := range n.Content {
+			node, err := uiNodeFromYAML(child)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Children = append(result.Children, node)
+		}
+
+		return result, nil
+	case yaml.MappingNode:
+		// noop
+	default:
+		return nil, ErrUIDefinition{Line: n.Line, Value: n.Value, Detail: errors.New("element should be an object")}
+	}
+
+	result := &UINode{Attrs: make(map[string]string), Line: n.Line}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i].Value, n.Content[i+1]
+
+		switch key {
+		case "type":
+			result.Kind = value.Value
+		case "children":
+			children, err := uiNodeFromYAML(value)
+			if err != nil {
+				return nil, err
+			}
+
+			if value.Kind != yaml.SequenceNode {
+				return nil, ErrUIDefinition{Line: value.Line, Value: key, Detail: errors.New("children should be a list")}
+			}
+
+			result.Children = children.Children
+		default:
+			switch value.Kind {
+			case yaml.ScalarNode:
+				result.Attrs[key] = value.Value
+			case yaml.SequenceNode:
+				items := make([]string, len(value.Content))
+				for i, item := range value.Content {
+					items[i] = item.Value
+				}
+
+				result.Attrs[key] = strings.Join(items, ",")
+			default:
+				return nil, ErrUIDefinition{Line: value.Line, Value: key, Detail: errors.New("attribute should be a scalar or a list")}
+			}
+		}
+	}
+
+	if result.Kind == "" {
+		return nil, ErrUIDefinition{Line: n.Line, Value: "type", Detail: errors.New("element without type")}
+	}
+
+	return result, nil
+}
+
+// UIBindings is a registry of values, callbacks and widgets which UI definitions refer to by name.
+// NOTE: register everything before loading definitions - the registry is read when a definition is (re)loaded.
+type UIBindings struct {
+	values    map[string]any
+	callbacks map[string]func()
+	widgets   map[string]func() Widget
+}
+
+// NewUIBindings creates an empty UIBindings.
+func NewUIBindings() *UIBindings {
+	return &UIBindings{
+		values:    make(map[string]any),
+		callbacks: make(map[string]func()),
+		widgets:   make(map[string]func() Widget),
+	}
+}
+
+// Value registers a value under name. It should be a pointer of the type the widget edits
+// (e.g. *string for InputText, *int32 for SliderInt) or an Observable (e.g. *Observable[string]).
+// Texts referring to values with "$name" (e.g. <Label text="$status"/>) accept any pointer or Signal.
+func (b *UIBindings) Value(name string, value any) *UIBindings {
+	b.values[name] = value
+	return b
+}
+
+// Callback registers a callback under name (e.g. <Button onClick="save"/>).
+func (b *UIBindings) Callback(name string, cb func()) *UIBindings {
+	b.callbacks[name] = cb
+	return b
+}
+
+// Widget registers a function creating a widget; it is placed by <Custom name="..."/> element.
+func (b *UIBindings) Widget(name string, w func() Widget) *UIBindings {
+	b.widgets[name] = w
+	return b
+}
+
+var _ Widget = &UIDefinition{}
+
+// UIDefinition is a Layout described by a declarative document (XML, JSON or YAML),
+// so screens may be edited without recompiling the program.
+// Elements are mapped to giu widgets (see RegisterUIElement), e.g.:
+//
+//	<Window title="Editor">
+//		<Row>
+//			<InputText value="name" hint="Name"/>
+//			<Button label="Save" onClick="save"/>
+//		</Row>
+//	</Window>
+//
+// values and callbacks are bound by names registered in UIBindings.
+type UIDefinition struct {
+	fileWatcher
+
+	format   string
+	bindings *UIBindings
+
+	m      *sync.Mutex
+	layout func() Layout
+	// err is the last reload error (nil if the last reload succeeded)
+	err  error
+	stop chan struct{}
+}
+
+// ParseUI parses UI definition (see ParseUINode for formats).
+// bindings may be nil for definitions which don't reference any values or callbacks.
+func ParseUI(data []byte, format string, bindings *UIBindings) (*UIDefinition, error) {
+	if bindings == nil {
+		bindings = NewUIBindings()
+	}
+
+	d := &UIDefinition{
+		format:   format,
+		bindings: bindings,
+		m:        &sync.Mutex{},
+	}
+
+	layout, err := d.compile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d.layout = layout
+
+	return d, nil
+}
+
+// LoadUI loads UI definition from file at path. The format is detected by extension (.xml, .json, .yaml or .yml).
+// bindings may be nil (see ParseUI). See also UIDefinition.Watch.
+func LoadUI(path string, bindings *UIBindings) (*UIDefinition, error) {
+	if bindings == nil {
+		bindings = NewUIBindings()
+	}
+
+	d := &UIDefinition{
+		fileWatcher: fileWatcher{path: path},
+		format:      strings.TrimPrefix(filepath.Ext(path), "."),
+		bindings:    bindings,
+		m:           &sync.Mutex{},
+	}
+
+	if _, err := d.changed(); err != nil {
+		return nil, err
+	}
+
+	layout, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+
+	d.layout = layout
+
+	return d, nil
+}
+
+// Watch starts polling the file (see UIWatchInterval) and reloads the definition whenever it changes on disk.
+// If it fails to load, the last good layout stays active and the error is shown above it.
+// It is noop for definitions created by ParseUI.
+func (d *UIDefinition) Watch() *UIDefinition {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if d.path == "" || d.stop != nil {
+		return d
+	}
+
+	d.stop = make(chan struct{})
+
+	go d.poll(UIWatchInterval, d.stop, d.reload)
+
+	return d
+}
+
+// StopWatching stops watching started by Watch.
+func (d *UIDefinition) StopWatching() {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+}
+
+// Err returns the last reload error (nil if the last reload succeeded).
+func (d *UIDefinition) Err() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	return d.err
+}
+
+// Layout creates widgets of the definition. As any widgets, they are meant to be built in the current frame.
+func (d *UIDefinition) Layout() Layout {
+	d.m.Lock()
+	layout := d.layout
+	d.m.Unlock()
+
+	return layout()
+}
+
+// Build implements Widget interface.
+func (d *UIDefinition) Build() {
+	if err := d.Err(); err != nil {
+		Style().SetColor(StyleColorText, colornames.Orangered).To(
+			Label(err.Error()).Wrapped(true),
+		).Build()
+	}
+
+	d.Layout().Build()
+}
+
+func (d *UIDefinition) load() (func() Layout, error) {
+	data, err := os.ReadFile(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading UI definition: %w", err)
+	}
+
+	layout, err := d.compile(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", d.path, err)
+	}
+
+	return layout, nil
+}
+
+func (d *UIDefinition) compile(data []byte) (func() Layout, error) {
+	root, err := ParseUINode(data, d.format)
+	if err != nil {
+		return nil, err
+	}
+
+	e := newUIElement(root, d.bindings)
+	build := e.compile()
+
+	if e.c.err != nil {
+		return nil, e.c.err
+	}
+
+	return func() Layout {
+		return Layout{build()}
+	}, nil
+}
+
+// reload loads the changed file.
+func (d *UIDefinition) reload() {
+	layout, err := d.load()
+
+	d.m.Lock()
+
+	if err == nil {
+		d.layout = layout
+	}
+
+	d.err = err
+
+	d.m.Unlock()
+
+	Update()
+}
@@ -0,0 +1,29 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_UIDefinition(t *testing.T) {
+	saved := 0
+
+	ui, err := giu.ParseUI([]byte(`<Row><Button label="Save" onClick="save"/></Row>`), "xml",
+		giu.NewUIBindings().Callback("save", func() { saved++ }))
+	if !assert.NoError(t, err, "parsing UI definition") {
+		return
+	}
+
+	h := giutest.New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{ui}
+	})
+
+	h.Click("Save")
+
+	assert.Equal(t, 1, saved, "callback not called")
+}
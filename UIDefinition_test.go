@@ -0,0 +1,152 @@
+package giu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testUIXML = `<Window title="Editor" width="300">
+	<Row>
+		<Label>Name:</Label>
+		<InputText value="name" onChange="changed"/>
+	</Row>
+	<Combo value="selected" items="First, Second"/>
+</Window>`
+
+const testUIYAML = `type: Window
+title: Editor
+width: 300
+children:
+  - type: Row
+    children:
+      - {type: Label, text: "Name:"}
+      - {type: InputText, value: name, onChange: changed}
+  - type: Combo
+    value: selected
+    items: [First, Second]
+`
+
+const testUIJSON = `{"type": "Window", "title": "Editor", "width": 300, "children": [
+	{"type": "Row", "children": [
+		{"type": "Label", "text": "Name:"},
+		{"type": "InputText", "value": "name", "onChange": "changed"}
+	]},
+	{"type": "Combo", "value": "selected", "items": "First, Second"}
+]}`
+
+// stripLines clears line numbers, so nodes parsed from different formats may be compared.
+func stripLines(n *UINode) *UINode {
+	n.Line = 0
+	for _, child := range n.Children {
+		stripLines(child)
+	}
+
+	return n
+}
+
+func TestParseUINode(t *testing.T) {
+	xmlNode, err := ParseUINode([]byte(testUIXML), "xml")
+	if !assert.NoError(t, err, "parsing XML") {
+		return
+	}
+
+	assert.Equal(t, 4, xmlNode.Children[0].Children[1].Line, "unexpected line of InputText")
+	assert.Equal(t, "Name:", xmlNode.Children[0].Children[0].Attrs["text"], "text content not stored")
+
+	yamlNode, err := ParseUINode([]byte(testUIYAML), "yaml")
+	if assert.NoError(t, err, "parsing YAML") {
+		yamlNode.Children[1].Attrs["items"] = "First, Second"
+		assert.Equal(t, stripLines(xmlNode), stripLines(yamlNode), "YAML parsed differently than XML")
+	}
+
+	jsonNode, err := ParseUINode([]byte(testUIJSON), "json")
+	if assert.NoError(t, err, "parsing JSON") {
+		assert.Equal(t, stripLines(xmlNode), stripLines(jsonNode), "JSON parsed differently than XML")
+	}
+
+	_, err = ParseUINode([]byte("<Row></Row><Row></Row>"), "xml")
+	assert.ErrorAs(t, err, &ErrUIDefinition{}, "more than one root accepted")
+
+	_, err = ParseUINode([]byte("- text: no type"), "yaml")
+	assert.ErrorAs(t, err, &ErrUIDefinition{}, "element without type accepted")
+}
+
+func TestParseUI(t *testing.T) {
+	var (
+		name     string
+		selected int32
+	)
+
+	bindings := NewUIBindings().
+		Value("name", &name).
+		Value("selected", &selected).
+		Callback("changed", func() {})
+
+	_, err := ParseUI([]byte(testUIXML), "xml", bindings)
+	assert.NoError(t, err, "valid definition rejected")
+
+	_, err = ParseUI([]byte("<Label>Hello</Label>"), "xml", nil)
+	assert.NoError(t, err, "definition without bindings rejected")
+
+	_, err = ParseUI([]byte(`<Label text="$status"/>`), "xml", nil)
+	assert.ErrorAs(t, err, &ErrUIDefinition{}, "unknown value accepted without bindings")
+
+	tests := []struct {
+		name    string
+		xml     string
+		element string
+	}{
+		{"unknown element", `<Row><Buton/></Row>`, "Row/Buton"},
+		{"unknown attribute", `<Row><Button lable="x"/></Row>`, "Row/Button"},
+		{"unknown callback", `<Button onClick="save"/>`, "Button"},
+		{"unknown value", `<Label text="$status"/>`, "Label"},
+		{"wrong value type", `<Checkbox value="name"/>`, "Checkbox"},
+		{"missing value", `<InputText/>`, "InputText"},
+		{"invalid number", `<Dummy width="wide"/>`, "Dummy"},
+		{"wrong child", `<TabBar><Label/></TabBar>`, "TabBar/Label"},
+		{"split children", `<SplitLayout><Label/></SplitLayout>`, "SplitLayout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseUI([]byte(tt.xml), "xml", bindings)
+
+			var uiErr ErrUIDefinition
+			if assert.ErrorAs(t, err, &uiErr, "invalid definition accepted") {
+				assert.Equal(t, tt.element, uiErr.Element, "unexpected element")
+			}
+		})
+	}
+}
+
+func TestFormatUIValue(t *testing.T) {
+	s, n := "text", 5
+
+	assert.Equal(t, "text", formatUIValue(&s), "pointer not dereferenced")
+	assert.Equal(t, "5", formatUIValue(&n), "pointer not dereferenced")
+	assert.Equal(t, "true", formatUIValue(NewObservable(true)), "signal not read")
+}
+
+func TestLoadUI(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui.xml")
+	assert.NoError(t, os.WriteFile(path, []byte("<Label>Hello</Label>"), 0o600), "writing definition")
+
+	d, err := LoadUI(path, nil)
+	if !assert.NoError(t, err, "loading definition") {
+		return
+	}
+
+	changed, _ := d.changed()
+	assert.False(t, changed, "file didn't change")
+
+	assert.NoError(t, os.WriteFile(path, []byte("<Label>Hello<Label>"), 0o600), "writing definition")
+
+	changed, _ = d.changed()
+	assert.True(t, changed, "change not detected")
+
+	_, err = d.load()
+	assert.ErrorAs(t, err, &ErrUIDefinition{}, "expected parsing error")
+}
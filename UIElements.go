@@ -0,0 +1,846 @@
+package giu
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UIElementFunc compiles element e of UI definition into a function creating its widget.
+// The returned function is called in every frame (as giu widgets are created in each frame),
+// so attributes should be read (and errors reported) by UIElementFunc, not by the returned function.
+type UIElementFunc func(e *UIElement) func() Widget
+
+// uiElements are elements available in UI definitions (see RegisterUIElement).
+var uiElements map[string]UIElementFunc
+
+//nolint:gochecknoinits // built-in elements refer to uiElements (through UIElement.Layout)
+func init() {
+	uiElements = map[string]UIElementFunc{
+		"Layout":       uiLayout,
+		"Window":       uiWindow,
+		"SingleWindow": uiSingleWindow,
+		"Row":          uiRow,
+		"Column":       uiColumn,
+		"Child":        uiChild,
+		"TreeNode":     uiTreeNode,
+		"TabBar":       uiTabBar,
+		"Table":        uiTable,
+		"SplitLayout":  uiSplitLayout,
+		"MenuBar":      uiMenuBar,
+		"Menu":         uiMenu,
+		"MenuItem":     uiMenuItem,
+		"Label":        uiLabel,
+		"BulletText":   uiBulletText,
+		"Button":       uiButton,
+		"Selectable":   uiSelectable,
+		"InputText":    uiInputText,
+		"InputInt":     uiInputInt,
+		"InputFloat":   uiInputFloat,
+		"Checkbox":     uiCheckbox,
+		"SliderInt":    uiSliderInt,
+		"SliderFloat":  uiSliderFloat,
+		"DragInt":      uiDragInt,
+		"DragFloat":    uiDragFloat,
+		"Combo":        uiCombo,
+		"ProgressBar":  uiProgressBar,
+		"Separator":    uiSeparator,
+		"Spacing":      uiSpacing,
+		"Dummy":        uiDummy,
+		"Custom":       uiCustom,
+	}
+}
+
+// RegisterUIElement makes element name available in UI definitions (it may replace a built-in element).
+// NOTE: call it before loading definitions.
+func RegisterUIElement(name string, f UIElementFunc) {
+	uiElements[name] = f
+}
+
+// uiCompiler is shared by all elements of a definition being compiled.
+type uiCompiler struct {
+	bindings *UIBindings
+	err      error
+}
+
+// UIElement is an element of UI definition being compiled (see UIElementFunc).
+// Its methods read attributes and children of the element.
+// The first error (e.g. invalid number, unknown binding) is returned by LoadUI/ParseUI.
+type UIElement struct {
+	node *UINode
+	path string
+	used map[string]bool
+	c    *uiCompiler
+}
+
+func newUIElement(node *UINode, bindings *UIBindings) *UIElement {
+	return (&UIElement{c: &uiCompiler{bindings: bindings}}).child(node)
+}
+
+func (e *UIElement) child(node *UINode) *UIElement {
+	path := node.Kind
+	if e.path != "" {
+		path = e.path + "/" + node.Kind
+	}
+
+	return &UIElement{
+		node: node,
+		path: path,
+		used: make(map[string]bool),
+		c:    e.c,
+	}
+}
+
+// Kind returns name of the element (e.g. "Button").
+func (e *UIElement) Kind() string {
+	return e.node.Kind
+}
+
+// Errorf reports an error of the element (only the first error of a definition is kept).
+func (e *UIElement) Errorf(value, format string, args ...any) {
+	if e.c.err != nil {
+		return
+	}
+
+	e.c.err = ErrUIDefinition{
+		Element: e.path,
+		Line:    e.node.Line,
+		Value:   value,
+		Detail:  fmt.Errorf(format, args...),
+	}
+}
+
+func (e *UIElement) attr(name string) (string, bool) {
+	e.used[name] = true
+	value, ok := e.node.Attrs[name]
+
+	return value, ok
+}
+
+// Has returns true if the element has attribute name.
+func (e *UIElement) Has(name string) bool {
+	_, ok := e.attr(name)
+	return ok
+}
+
+// String returns attribute name (or def if it isn't set).
+func (e *UIElement) String(name, def string) string {
+	if value, ok := e.attr(name); ok {
+		return value
+	}
+
+	return def
+}
+
+// Text returns a function returning attribute name (or def if it isn't set).
+// If the attribute is "$value", the function returns the current value bound as "value"
+// (it may be any pointer or Signal); "$$" is an escaped "$".
+func (e *UIElement) Text(name, def string) func() string {
+	value := e.String(name, def)
+
+	switch {
+	case strings.HasPrefix(value, "$$"):
+		value = value[1:]
+	case strings.HasPrefix(value, "$"):
+		bound, ok := e.c.bindings.values[value[1:]]
+		if !ok {
+			e.Errorf(value, "unknown value")
+			break
+		}
+
+		return func() string {
+			return formatUIValue(bound)
+		}
+	}
+
+	return func() string {
+		return value
+	}
+}
+
+// formatUIValue formats a value bound by UIBindings.Value: pointers are dereferenced and Get is called on signals.
+func formatUIValue(value any) string {
+	v := reflect.ValueOf(value)
+
+	if get := v.MethodByName("Get"); get.IsValid() && get.Type().NumIn() == 0 && get.Type().NumOut() == 1 {
+		return fmt.Sprint(get.Call(nil)[0].Interface())
+	}
+
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		return fmt.Sprint(v.Elem().Interface())
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Float returns attribute name as a number (or def if it isn't set).
+func (e *UIElement) Float(name string, def float32) float32 {
+	value, ok := e.attr(name)
+	if !ok {
+		return def
+	}
+
+	result, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		e.Errorf(value, "%s should be a number", name)
+	}
+
+	return float32(result)
+}
+
+// Int returns attribute name as an integer (or def if it isn't set).
+func (e *UIElement) Int(name string, def int32) int32 {
+	value, ok := e.attr(name)
+	if !ok {
+		return def
+	}
+
+	result, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		e.Errorf(value, "%s should be an integer", name)
+	}
+
+	return int32(result)
+}
+
+// Bool returns attribute name as a boolean (or def if it isn't set).
+func (e *UIElement) Bool(name string, def bool) bool {
+	value, ok := e.attr(name)
+	if !ok {
+		return def
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		e.Errorf(value, "%s should be true or false", name)
+	}
+
+	return result
+}
+
+// List returns a function returning attribute name split by commas.
+// If the attribute is "$value", the function returns []string (or *[]string) bound as "value".
+func (e *UIElement) List(name string) func() []string {
+	value := e.String(name, "")
+
+	if bound, ok := strings.CutPrefix(value, "$"); ok && !strings.HasPrefix(bound, "$") {
+		switch list := e.c.bindings.values[bound].(type) {
+		case []string:
+			return func() []string { return list }
+		case *[]string:
+			return func() []string { return *list }
+		default:
+			e.Errorf(value, "value should be []string or *[]string (got %T)", list)
+		}
+	}
+
+	value = strings.TrimPrefix(value, "$")
+
+	var result []string
+	if value != "" {
+		result = strings.Split(value, ",")
+		for i := range result {
+			result[i] = strings.TrimSpace(result[i])
+		}
+	}
+
+	return func() []string {
+		return result
+	}
+}
+
+// Callback returns callback which name is attribute name (nil if the attribute isn't set).
+func (e *UIElement) Callback(name string) func() {
+	value, ok := e.attr(name)
+	if !ok {
+		return nil
+	}
+
+	cb, ok := e.c.bindings.callbacks[value]
+	if !ok {
+		e.Errorf(value, "unknown callback")
+	}
+
+	return cb
+}
+
+// Value returns value which name is attribute name. The attribute is required.
+func (e *UIElement) Value(name string) any {
+	value, ok := e.attr(name)
+	if !ok {
+		e.Errorf(name, "attribute is required")
+		return nil
+	}
+
+	result, ok := e.c.bindings.values[value]
+	if !ok {
+		e.Errorf(value, "unknown value")
+	}
+
+	return result
+}
+
+// uiValue returns value bound to attribute name of e. It is either a pointer or an Observable (the other is nil).
+func uiValue[T any](e *UIElement, name string) (*T, *Observable[T]) {
+	switch value := e.Value(name).(type) {
+	case *T:
+		return value, nil
+	case *Observable[T]:
+		return nil, value
+	case nil:
+		return new(T), nil
+	default:
+		e.Errorf(e.String(name, ""), "value should be %T or %T (got %T)", (*T)(nil), (*Observable[T])(nil), value)
+		return new(T), nil
+	}
+}
+
+// uiPointer is like uiValue, but it doesn't accept Observable.
+func uiPointer[T any](e *UIElement, name string) *T {
+	switch value := e.Value(name).(type) {
+	case *T:
+		return value
+	case nil:
+		return new(T)
+	default:
+		e.Errorf(e.String(name, ""), "value should be %T (got %T)", (*T)(nil), value)
+		return new(T)
+	}
+}
+
+// Elements returns children of the element.
+func (e *UIElement) Elements() []*UIElement {
+	result := make([]*UIElement, len(e.node.Children))
+	for i, child := range e.node.Children {
+		result[i] = e.child(child)
+	}
+
+	return result
+}
+
+// Layout compiles children of the element. The returned function creates their widgets.
+func (e *UIElement) Layout() func() Layout {
+	children := e.Elements()
+	builds := make([]func() Widget, len(children))
+
+	for i, child := range children {
+		builds[i] = child.compile()
+	}
+
+	return func() Layout {
+		result := make(Layout, len(builds))
+		for i, build := range builds {
+			result[i] = build()
+		}
+
+		return result
+	}
+}
+
+// compile compiles the element using its UIElementFunc.
+// "class" and "cssId" attributes are applied to any widget supporting CSS (see CSSStylesheet).
+func (e *UIElement) compile() func() Widget {
+	f, ok := uiElements[e.node.Kind]
+	if !ok {
+		e.Errorf(e.node.Kind, "unknown element")
+
+		return func() Widget {
+			return Layout{}
+		}
+	}
+
+	build := f(e)
+	classes := e.String("class", "")
+	cssID := e.String("cssId", "")
+
+	e.checkAttributes()
+
+	if classes == "" && cssID == "" {
+		return build
+	}
+
+	return func() Widget {
+		w := build()
+
+		if a, ok := w.(interface{ cssAttrs() *cssAttributes }); ok {
+			attrs := a.cssAttrs()
+			attrs.addClasses([]string{classes})
+
+			if cssID != "" {
+				attrs.cssID = cssID
+			}
+		}
+
+		return w
+	}
+}
+
+// checkAttributes reports attributes which weren't read (most likely typos).
+func (e *UIElement) checkAttributes() {
+	for _, name := range slices.Sorted(maps.Keys(e.node.Attrs)) {
+		if !e.used[name] {
+			e.Errorf(name, "unknown attribute")
+		}
+	}
+}
+
+func uiLayout(e *UIElement) func() Widget {
+	layout := e.Layout()
+
+	return func() Widget {
+		return layout()
+	}
+}
+
+func uiWindow(e *UIElement) func() Widget {
+	title := e.Text("title", "")
+	x, y := e.Float("x", 0), e.Float("y", 0)
+	width, height := e.Float("width", 0), e.Float("height", 0)
+	hasPos, hasSize := e.Has("x") || e.Has("y"), e.Has("width") || e.Has("height")
+	layout := e.Layout()
+
+	return func() Widget {
+		return Custom(func() {
+			w := Window(title())
+
+			if hasPos {
+				w.Pos(x, y)
+			}
+
+			if hasSize {
+				w.Size(width, height)
+			}
+
+			w.Layout(layout()...)
+		})
+	}
+}
+
+func uiSingleWindow(e *UIElement) func() Widget {
+	layout := e.Layout()
+
+	return func() Widget {
+		return Custom(func() {
+			SingleWindow().Layout(layout()...)
+		})
+	}
+}
+
+func uiRow(e *UIElement) func() Widget {
+	layout := e.Layout()
+
+	return func() Widget {
+		return Row(layout()...)
+	}
+}
+
+func uiColumn(e *UIElement) func() Widget {
+	layout := e.Layout()
+
+	return func() Widget {
+		return Column(layout()...)
+	}
+}
+
+func uiChild(e *UIElement) func() Widget {
+	width, height := e.Float("width", 0), e.Float("height", 0)
+	border := e.Bool("border", true)
+	layout := e.Layout()
+
+	return func() Widget {
+		return Child().Size(width, height).Border(border).Layout(layout()...)
+	}
+}
+
+func uiTreeNode(e *UIElement) func() Widget {
+	label := e.Text("label", "")
+	layout := e.Layout()
+
+	return func() Widget {
+		return TreeNode(label()).Layout(layout()...)
+	}
+}
+
+func uiTabBar(e *UIElement) func() Widget {
+	type tabItem struct {
+		label  func() string
+		layout func() Layout
+	}
+
+	var items []tabItem
+
+	for _, child := range e.Elements() {
+		if child.Kind() != "TabItem" {
+			child.Errorf(child.Kind(), "TabBar may contain TabItem elements only")
+			continue
+		}
+
+		items = append(items, tabItem{label: child.Text("label", ""), layout: child.Layout()})
+		child.checkAttributes()
+	}
+
+	return func() Widget {
+		tabs := make([]*TabItemWidget, len(items))
+		for i, item := range items {
+			tabs[i] = TabItem(item.label()).Layout(item.layout()...)
+		}
+
+		return TabBar().TabItems(tabs...)
+	}
+}
+
+func uiTable(e *UIElement) func() Widget {
+	var (
+		columns []func() string
+		rows    []func() Layout
+	)
+
+	width, height := e.Float("width", 0), e.Float("height", 0)
+	noHeader := e.Bool("noHeader", false)
+
+	for _, child := range e.Elements() {
+		switch child.Kind() {
+		case "TableColumn":
+			columns = append(columns, child.Text("label", ""))
+		case "TableRow":
+			rows = append(rows, child.Layout())
+		default:
+			child.Errorf(child.Kind(), "Table may contain TableColumn and TableRow elements only")
+			continue
+		}
+
+		child.checkAttributes()
+	}
+
+	return func() Widget {
+		cols := make([]*TableColumnWidget, len(columns))
+		for i, label := range columns {
+			cols[i] = TableColumn(label())
+		}
+
+		tableRows := make([]*TableRowWidget, len(rows))
+		for i, row := range rows {
+			tableRows[i] = TableRow(row()...)
+		}
+
+		return Table().Size(width, height).NoHeader(noHeader).Columns(cols...).Rows(tableRows...)
+	}
+}
+
+func uiSplitLayout(e *UIElement) func() Widget {
+	direction := DirectionHorizontal
+
+	switch value := e.String("direction", "horizontal"); value {
+	case "horizontal":
+		// noop
+	case "vertical":
+		direction = DirectionVertical
+	default:
+		e.Errorf(value, "direction should be horizontal or vertical")
+	}
+
+	var sashPos *float32
+	if e.Has("value") {
+		sashPos = uiPointer[float32](e, "value")
+	} else {
+		position := e.Float("position", 200)
+		sashPos = &position
+	}
+
+	children := e.Elements()
+	if len(children) != 2 {
+		e.Errorf(e.Kind(), "SplitLayout should have 2 children (has %d)", len(children))
+		return func() Widget { return Layout{} }
+	}
+
+	layout1, layout2 := children[0].compile(), children[1].compile()
+
+	return func() Widget {
+		return SplitLayout(direction, sashPos, layout1(), layout2())
+	}
+}
+
+func uiMenuBar(e *UIElement) func() Widget {
+	layout := e.Layout()
+
+	return func() Widget {
+		return MenuBar().Layout(layout()...)
+	}
+}
+
+func uiMenu(e *UIElement) func() Widget {
+	label := e.Text("label", "")
+	layout := e.Layout()
+
+	return func() Widget {
+		return Menu(label()).Layout(layout()...)
+	}
+}
+
+func uiMenuItem(e *UIElement) func() Widget {
+	label := e.Text("label", "")
+	shortcut := e.String("shortcut", "")
+	onClick := e.Callback("onClick")
+
+	return func() Widget {
+		return MenuItem(label()).Shortcut(shortcut).OnClick(onClick)
+	}
+}
+
+func uiLabel(e *UIElement) func() Widget {
+	text := e.Text("text", "")
+	wrapped := e.Bool("wrapped", false)
+
+	return func() Widget {
+		return Label(text()).Wrapped(wrapped)
+	}
+}
+
+func uiBulletText(e *UIElement) func() Widget {
+	text := e.Text("text", "")
+
+	return func() Widget {
+		return BulletText(text())
+	}
+}
+
+func uiButton(e *UIElement) func() Widget {
+	label := e.Text("label", "")
+	width, height := e.Float("width", 0), e.Float("height", 0)
+	disabled := e.Bool("disabled", false)
+	onClick := e.Callback("onClick")
+
+	return func() Widget {
+		return Button(label()).Size(width, height).Disabled(disabled).OnClick(onClick)
+	}
+}
+
+func uiSelectable(e *UIElement) func() Widget {
+	label := e.Text("label", "")
+	onClick := e.Callback("onClick")
+
+	return func() Widget {
+		return Selectable(label()).OnClick(onClick)
+	}
+}
+
+func uiInputText(e *UIElement) func() Widget {
+	value, o := uiValue[string](e, "value")
+	label := e.Text("label", "")
+	hint := e.String("hint", "")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		var w *InputTextWidget
+		if o != nil {
+			w = InputTextBound(o)
+		} else {
+			w = InputText(value)
+		}
+
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.Hint(hint).Size(width).OnChange(onChange)
+	}
+}
+
+func uiInputInt(e *UIElement) func() Widget {
+	value := uiPointer[int32](e, "value")
+	label := e.Text("label", "")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		w := InputInt(value)
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.Size(width).OnChange(onChange)
+	}
+}
+
+func uiInputFloat(e *UIElement) func() Widget {
+	value := uiPointer[float32](e, "value")
+	label := e.Text("label", "")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		w := InputFloat(value)
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.Size(width).OnChange(onChange)
+	}
+}
+
+func uiCheckbox(e *UIElement) func() Widget {
+	value, o := uiValue[bool](e, "value")
+	label := e.Text("label", "")
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		if o != nil {
+			return CheckboxBound(label(), o).OnChange(onChange)
+		}
+
+		return Checkbox(label(), value).OnChange(onChange)
+	}
+}
+
+func uiSliderInt(e *UIElement) func() Widget {
+	value := uiPointer[int32](e, "value")
+	minValue, maxValue := e.Int("min", 0), e.Int("max", 100)
+	label := e.Text("label", "")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		w := SliderInt(value, minValue, maxValue)
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.Size(width).OnChange(onChange)
+	}
+}
+
+func uiSliderFloat(e *UIElement) func() Widget {
+	value, o := uiValue[float32](e, "value")
+	minValue, maxValue := e.Float("min", 0), e.Float("max", 1)
+	label := e.Text("label", "")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		var w *SliderFloatWidget
+		if o != nil {
+			w = SliderFloatBound(o, minValue, maxValue)
+		} else {
+			w = SliderFloat(value, minValue, maxValue)
+		}
+
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.Size(width).OnChange(onChange)
+	}
+}
+
+func uiDragInt(e *UIElement) func() Widget {
+	value := uiPointer[int32](e, "value")
+	label := e.Text("label", "")
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		w := DragInt(value)
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.OnChange(onChange)
+	}
+}
+
+func uiDragFloat(e *UIElement) func() Widget {
+	value := uiPointer[float32](e, "value")
+	label := e.Text("label", "")
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		w := DragFloat(value)
+		if text := label(); text != "" {
+			w.Label(text)
+		}
+
+		return w.OnChange(onChange)
+	}
+}
+
+func uiCombo(e *UIElement) func() Widget {
+	value, o := uiValue[int32](e, "value")
+	label := e.Text("label", "")
+	items := e.List("items")
+	width := e.Float("width", 0)
+	onChange := e.Callback("onChange")
+
+	return func() Widget {
+		list := items()
+		if o != nil {
+			return ComboBound(label(), list, o).Size(width).OnChange(onChange)
+		}
+
+		var preview string
+		if *value >= 0 && int(*value) < len(list) {
+			preview = list[*value]
+		}
+
+		return Combo(label(), preview, list, value).Size(width).OnChange(onChange)
+	}
+}
+
+func uiProgressBar(e *UIElement) func() Widget {
+	var value func() float32
+
+	if e.Has("value") {
+		ptr, o := uiValue[float32](e, "value")
+		value = func() float32 {
+			if o != nil {
+				return o.Get()
+			}
+
+			return *ptr
+		}
+	} else {
+		fraction := e.Float("fraction", 0)
+		value = func() float32 { return fraction }
+	}
+
+	overlay := e.Text("overlay", "")
+	width, height := e.Float("width", 0), e.Float("height", 0)
+
+	return func() Widget {
+		return ProgressBar(value()).Overlay(overlay()).Size(width, height)
+	}
+}
+
+func uiSeparator(*UIElement) func() Widget {
+	return func() Widget {
+		return Separator()
+	}
+}
+
+func uiSpacing(*UIElement) func() Widget {
+	return func() Widget {
+		return Spacing()
+	}
+}
+
+func uiDummy(e *UIElement) func() Widget {
+	width, height := e.Float("width", 0), e.Float("height", 0)
+
+	return func() Widget {
+		return Dummy(width, height)
+	}
+}
+
+func uiCustom(e *UIElement) func() Widget {
+	name := e.String("name", "")
+
+	w, ok := e.c.bindings.widgets[name]
+	if !ok {
+		e.Errorf(name, "unknown widget")
+		return func() Widget { return Layout{} }
+	}
+
+	return w
+}
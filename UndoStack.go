@@ -0,0 +1,225 @@
+package giu
+
+// Command is an action which can be undone (see UndoStack).
+type Command interface {
+	// Do applies (or re-applies after Undo) the command.
+	Do()
+	// Undo reverts the command.
+	Undo()
+}
+
+var _ Command = &FuncCommand{}
+
+// FuncCommand is a Command made of two functions.
+type FuncCommand struct {
+	DoFunc   func()
+	UndoFunc func()
+}
+
+// NewCommand creates a Command calling do and undo.
+func NewCommand(do, undo func()) *FuncCommand {
+	return &FuncCommand{
+		DoFunc:   do,
+		UndoFunc: undo,
+	}
+}
+
+// Do implements Command interface.
+func (c *FuncCommand) Do() {
+	if c.DoFunc != nil {
+		c.DoFunc()
+	}
+}
+
+// Undo implements Command interface.
+func (c *FuncCommand) Undo() {
+	if c.UndoFunc != nil {
+		c.UndoFunc()
+	}
+}
+
+// valueCommand is a change of widget's value recorded by trackEdit.
+type valueCommand[T any] struct {
+	set           func(T)
+	before, after T
+}
+
+func (c *valueCommand[T]) Do() {
+	c.set(c.after)
+}
+
+func (c *valueCommand[T]) Undo() {
+	c.set(c.before)
+}
+
+// UndoStack records commands, so they can be undone and redone.
+// Value changes made by widgets with UndoStack set (e.g. InputText(&s).UndoStack(stack))
+// are recorded automatically: all edits made while the widget is active
+// (e.g. typing into a field or dragging a slider) are one command.
+// NOTE: UndoStack is not thread-safe - use it on the UI thread (see Invoke).
+type UndoStack struct {
+	done     []Command
+	undone   []Command
+	limit    int
+	onChange func()
+	// values of active widgets from before their activation (by widget ID)
+	pending map[ID]any
+}
+
+// NewUndoStack creates a new, empty UndoStack.
+func NewUndoStack() *UndoStack {
+	return &UndoStack{
+		pending: make(map[ID]any),
+	}
+}
+
+// Limit sets the maximum number of commands which can be undone (0 means no limit).
+// The oldest commands are forgotten.
+func (u *UndoStack) Limit(limit int) *UndoStack {
+	u.limit = limit
+	u.trim()
+
+	return u
+}
+
+// OnChange sets callback called after a command is recorded, undone or redone.
+func (u *UndoStack) OnChange(cb func()) *UndoStack {
+	u.onChange = cb
+	return u
+}
+
+// Push applies cmd and records it. Commands which were undone can't be redone anymore.
+func (u *UndoStack) Push(cmd Command) {
+	cmd.Do()
+	u.record(cmd)
+}
+
+// record records already applied cmd.
+func (u *UndoStack) record(cmd Command) {
+	u.done = append(u.done, cmd)
+	u.undone = nil
+	u.trim()
+	u.changed()
+}
+
+func (u *UndoStack) trim() {
+	if u.limit > 0 && len(u.done) > u.limit {
+		u.done = append([]Command(nil), u.done[len(u.done)-u.limit:]...)
+	}
+}
+
+func (u *UndoStack) changed() {
+	if u.onChange != nil {
+		u.onChange()
+	}
+}
+
+// CanUndo returns true if there is a command to undo.
+func (u *UndoStack) CanUndo() bool {
+	return len(u.done) > 0
+}
+
+// CanRedo returns true if there is an undone command to redo.
+func (u *UndoStack) CanRedo() bool {
+	return len(u.undone) > 0
+}
+
+// Undo reverts the last command. It returns false if there was nothing to undo.
+func (u *UndoStack) Undo() bool {
+	if !u.CanUndo() {
+		return false
+	}
+
+	cmd := u.done[len(u.done)-1]
+	u.done = u.done[:len(u.done)-1]
+	cmd.Undo()
+	u.undone = append(u.undone, cmd)
+	u.changed()
+
+	return true
+}
+
+// Redo re-applies the last undone command. It returns false if there was nothing to redo.
+func (u *UndoStack) Redo() bool {
+	if !u.CanRedo() {
+		return false
+	}
+
+	cmd := u.undone[len(u.undone)-1]
+	u.undone = u.undone[:len(u.undone)-1]
+	cmd.Do()
+	u.done = append(u.done, cmd)
+	u.changed()
+
+	return true
+}
+
+// Clear forgets all commands.
+func (u *UndoStack) Clear() {
+	u.done, u.undone = nil, nil
+	clear(u.pending)
+	u.changed()
+}
+
+// Shortcuts returns the default bindings: Ctrl+Z (undo) and Ctrl+Shift+Z (redo)
+// named "edit.undo" and "edit.redo" (so they may be changed by a Keymap).
+// They may be registered for a window (see WindowWidget.RegisterNamedShortcuts).
+func (u *UndoStack) Shortcuts() map[string]WindowShortcut {
+	return map[string]WindowShortcut{
+		"edit.undo": {Key: KeyZ, Modifier: ModControl, Callback: u.shortcut(u.Undo)},
+		"edit.redo": {Key: KeyZ, Modifier: ModControl | ModShift, Callback: u.shortcut(u.Redo)},
+	}
+}
+
+// RegisterShortcuts registers Shortcuts as global keyboard shortcuts in Context.InputHandler.
+func (u *UndoStack) RegisterShortcuts() *UndoStack {
+	registerNamedShortcuts(u.Shortcuts(), GlobalShortcut)
+
+	return u
+}
+
+// shortcut wraps f so it doesn't run while a text field is edited (it has own undo).
+func (u *UndoStack) shortcut(f func() bool) func() {
+	return func() {
+		if Context.IO().WantTextInput() {
+			return
+		}
+
+		f()
+	}
+}
+
+// trackEdit records change of value made by the last built item (id is its ID).
+// before is value from before the item was built; bind (if not nil) is called after undo/redo sets the value.
+// Changes made while the item is active are coalesced into one command (see EventHandler.OnActivate).
+func trackEdit[T comparable](u *UndoStack, id ID, value *T, before T, bind func()) {
+	if u == nil {
+		return
+	}
+
+	set := func(v T) {
+		*value = v
+
+		if bind != nil {
+			bind()
+		}
+	}
+
+	_, wasActive := u.pending[id]
+
+	Event().OnActivate(func() {
+		u.pending[id] = before
+	}).OnDeactivate(func() {
+		start, ok := u.pending[id].(T)
+		delete(u.pending, id)
+
+		if ok && start != *value {
+			u.record(&valueCommand[T]{set: set, before: start, after: *value})
+		}
+	}).Build()
+
+	// changes made without activating the item (e.g. in combo's popup)
+	if !wasActive && !IsItemActive() && before != *value {
+		u.record(&valueCommand[T]{set: set, before: before, after: *value})
+	}
+}
@@ -0,0 +1,35 @@
+package giu_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+	"github.com/AllenDang/giu/giutest"
+)
+
+func Test_Harness_UndoStack(t *testing.T) {
+	stack := giu.NewUndoStack()
+	enabled := false
+
+	h := giutest.New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{
+			giu.Checkbox("Enabled", &enabled).UndoStack(stack),
+		}
+	})
+
+	h.Click("Enabled")
+	h.Step(2)
+
+	assert.True(t, enabled, "checkbox not toggled")
+
+	if !assert.True(t, stack.Undo(), "change not recorded") {
+		return
+	}
+
+	assert.False(t, enabled, "change not undone")
+	assert.True(t, stack.Redo(), "change not redoable")
+	assert.True(t, enabled, "change not redone")
+}
@@ -0,0 +1,53 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoStack(t *testing.T) {
+	value := 0
+	set := func(v int) { value = v }
+	changes := 0
+
+	stack := NewUndoStack().OnChange(func() { changes++ })
+
+	assert.False(t, stack.Undo(), "undo of empty stack")
+
+	stack.Push(&valueCommand[int]{set: set, before: 0, after: 1})
+	stack.Push(&valueCommand[int]{set: set, before: 1, after: 2})
+	assert.Equal(t, 2, value, "Push didn't apply command")
+
+	assert.True(t, stack.Undo(), "nothing undone")
+	assert.Equal(t, 1, value, "unexpected value after undo")
+	assert.True(t, stack.CanRedo(), "undone command can't be redone")
+
+	assert.True(t, stack.Redo(), "nothing redone")
+	assert.Equal(t, 2, value, "unexpected value after redo")
+
+	stack.Undo()
+	stack.Push(NewCommand(func() { value = 10 }, func() { value = 1 }))
+	assert.False(t, stack.CanRedo(), "redo possible after push")
+
+	stack.Undo()
+	stack.Undo()
+	assert.Equal(t, 0, value, "unexpected value after undoing everything")
+	assert.False(t, stack.CanUndo(), "unexpected command left")
+	assert.Equal(t, 8, changes, "unexpected number of OnChange calls")
+}
+
+func TestUndoStack_Limit(t *testing.T) {
+	value := 0
+	stack := NewUndoStack().Limit(2)
+
+	for i := 1; i <= 3; i++ {
+		stack.Push(&valueCommand[int]{set: func(v int) { value = v }, before: i - 1, after: i})
+	}
+
+	for stack.CanUndo() {
+		stack.Undo()
+	}
+
+	assert.Equal(t, 1, value, "the oldest command not forgotten")
+}
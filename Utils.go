@@ -51,6 +51,26 @@ func LoadImage(imgPath string) (*image.RGBA, error) {
 	return PNGToRgba(imgFile)
 }
 
+// SaveImage encodes img as PNG and saves it in imgPath.
+func SaveImage(imgPath string, img image.Image) (err error) {
+	imgFile, err := os.Create(filepath.Clean(imgPath))
+	if err != nil {
+		return fmt.Errorf("SaveImage: error creating image file %s: %w", imgPath, err)
+	}
+
+	defer func() {
+		if closeErr := imgFile.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("SaveImage: error closing image file %s: %w", imgPath, closeErr)
+		}
+	}()
+
+	if err := png.Encode(imgFile, img); err != nil {
+		return fmt.Errorf("SaveImage: error encoding png image: %w", err)
+	}
+
+	return nil
+}
+
 // ImageToRgba converts image.Image to *image.RGBA.
 func ImageToRgba(img image.Image) *image.RGBA {
 	switch trueImg := img.(type) {
@@ -218,19 +238,23 @@ func PopClipRect() {
 	imgui.PopClipRect()
 }
 
-// Assert checks if cond. If not cond, it alls golang panic.
+// Assert checks if cond. If not cond, it reports ErrWidget to the error handler of Context
+// (see GIUContext.SetErrorHandler). By default, it calls golang panic.
+// NOTE: Assert returns if the error handler doesn't panic (e.g. LogErrors), so callers must handle !cond too.
 func Assert(cond bool, t, method, msg string, args ...any) {
-	if !cond {
-		fatal(t, method, msg, args...)
-	}
+	AssertID(cond, t, method, "", msg, args...)
 }
 
-func fatal(widgetName, method, message string, args ...any) {
-	if widgetName != "" {
-		widgetName = fmt.Sprintf("(*%s)", widgetName)
+// AssertID is like Assert, but it also reports ID of the widget (see ErrWidget.ID).
+func AssertID(cond bool, t, method string, id ID, msg string, args ...any) {
+	if !cond {
+		reportError(ErrWidget{
+			Widget:  t,
+			Method:  method,
+			ID:      id,
+			Message: fmt.Sprintf(msg, args...),
+		})
 	}
-
-	log.Panicf("giu: %s.%s: %s", widgetName, method, fmt.Sprintf(message, args...))
 }
 
 // OpenURL opens `url` in default browser.
@@ -14,6 +14,8 @@ var _ Widget = &RowWidget{}
 // calls imgui.SameLine().
 type RowWidget struct {
 	widgets Layout
+
+	cssAttributes
 }
 
 // Row creates RowWidget.
@@ -23,6 +25,18 @@ func Row(widgets ...Widget) *RowWidget {
 	}
 }
 
+// Class adds CSS classes to the row (matched by .class selectors).
+func (l *RowWidget) Class(classes ...string) *RowWidget {
+	l.addClasses(classes)
+	return l
+}
+
+// CSSID sets CSS ID of the row (matched by #id selectors).
+func (l *RowWidget) CSSID(id string) *RowWidget {
+	l.cssID = id
+	return l
+}
+
 // Build implements Widget interface.
 func (l *RowWidget) Build() {
 	isFirst := true
@@ -45,7 +59,7 @@ func (l *RowWidget) Build() {
 			}
 		}
 
-		w.Build()
+		buildWidget(w)
 	})
 }
 
@@ -66,6 +80,8 @@ type ChildWidget struct {
 	border bool
 	flags  WindowFlags
 	layout Layout
+
+	cssAttributes
 }
 
 // Child creates a new ChildWidget.
@@ -111,6 +127,18 @@ func (c *ChildWidget) ID(id ID) *ChildWidget {
 	return c
 }
 
+// Class adds CSS classes to the child (matched by .class selectors).
+func (c *ChildWidget) Class(classes ...string) *ChildWidget {
+	c.addClasses(classes)
+	return c
+}
+
+// CSSID sets CSS ID of the child (matched by #id selectors).
+func (c *ChildWidget) CSSID(id string) *ChildWidget {
+	c.cssID = id
+	return c
+}
+
 // Build makes a Child.
 func (c *ChildWidget) Build() {
 	if imgui.BeginChildStrV(c.id.String(), imgui.Vec2{X: c.width, Y: c.height}, func() imgui.ChildFlags {
@@ -204,6 +232,12 @@ type ComboWidget struct {
 	filter       bool
 	filterLabel  ID
 	onChange     func()
+	undo         *UndoStack
+
+	// bind writes value back to the bound Observable (see ComboBound)
+	bind func()
+
+	cssAttributes
 }
 
 // Combo creates a new ComboWidget.
@@ -251,6 +285,24 @@ func (c *ComboWidget) OnChange(onChange func()) *ComboWidget {
 	return c
 }
 
+// UndoStack sets stack recording changes of the combo's value (see UndoStack).
+func (c *ComboWidget) UndoStack(stack *UndoStack) *ComboWidget {
+	c.undo = stack
+	return c
+}
+
+// Class adds CSS classes to the combo (matched by .class selectors).
+func (c *ComboWidget) Class(classes ...string) *ComboWidget {
+	c.addClasses(classes)
+	return c
+}
+
+// CSSID sets CSS ID of the combo (matched by #id selectors).
+func (c *ComboWidget) CSSID(id string) *ComboWidget {
+	c.cssID = id
+	return c
+}
+
 // Build implements Widget interface.
 func (c *ComboWidget) Build() {
 	if c.width > 0 {
@@ -267,6 +319,8 @@ func (c *ComboWidget) Build() {
 		}
 	}
 
+	before := *c.selected
+
 	if imgui.BeginComboV(Context.PrepareString(c.label.String()), c.previewValue, imgui.ComboFlags(c.flags)) {
 		if c.filter {
 			if imgui.IsWindowAppearing() {
@@ -284,6 +338,10 @@ func (c *ComboWidget) Build() {
 
 			if imgui.SelectableBool(fmt.Sprintf("%s##%d", Context.PrepareString(item), i)) {
 				*c.selected = int32(i)
+				if c.bind != nil {
+					c.bind()
+				}
+
 				if c.onChange != nil {
 					c.onChange()
 				}
@@ -292,6 +350,8 @@ func (c *ComboWidget) Build() {
 
 		imgui.EndCombo()
 	}
+
+	trackEdit(c.undo, c.label, c.selected, before, c.bind)
 }
 
 var _ Widget = &ContextMenuWidget{}
@@ -344,6 +404,8 @@ var _ Widget = &ColumnWidget{}
 // ColumnWidget will place all widgets one by one vertically.
 type ColumnWidget struct {
 	widgets Layout
+
+	cssAttributes
 }
 
 // Column creates a new ColumnWidget.
@@ -353,6 +415,18 @@ func Column(widgets ...Widget) *ColumnWidget {
 	}
 }
 
+// Class adds CSS classes to the column (matched by .class selectors).
+func (g *ColumnWidget) Class(classes ...string) *ColumnWidget {
+	g.addClasses(classes)
+	return g
+}
+
+// CSSID sets CSS ID of the column (matched by #id selectors).
+func (g *ColumnWidget) CSSID(id string) *ColumnWidget {
+	g.cssID = id
+	return g
+}
+
 // Build implements Widget interface.
 func (g *ColumnWidget) Build() {
 	imgui.BeginGroup()
@@ -430,6 +504,9 @@ type MenuItemWidget struct {
 	selected bool
 	enabled  bool
 	onClick  func()
+	command  string
+
+	cssAttributes
 }
 
 // MenuItem creates new MenuItemWidget.
@@ -445,7 +522,7 @@ func MenuItem(label string) *MenuItemWidget {
 
 // MenuItemf creates MenuItem with formated label.
 func MenuItemf(format string, args ...any) *MenuItemWidget {
-	return MenuItem(fmt.Sprintf(format, args...))
+	return MenuItem(Context.PrepareStringf(format, args...))
 }
 
 // Shortcut sets shortcut of the item (grayed, right-aligned text). Used for presenting e.g. keyboard shortcuts (e.g. "Ctrl+S")
@@ -473,10 +550,40 @@ func (m *MenuItemWidget) OnClick(onClick func()) *MenuItemWidget {
 	return m
 }
 
+// Command makes the item run the command name registered by RegisterCommands (instead of OnClick)
+// and show its shortcut (if Shortcut is not set).
+func (m *MenuItemWidget) Command(name string) *MenuItemWidget {
+	m.command = name
+	return m
+}
+
+// Class adds CSS classes to the menu item (matched by .class selectors).
+func (m *MenuItemWidget) Class(classes ...string) *MenuItemWidget {
+	m.addClasses(classes)
+	return m
+}
+
+// CSSID sets CSS ID of the menu item (matched by #id selectors).
+func (m *MenuItemWidget) CSSID(id string) *MenuItemWidget {
+	m.cssID = id
+	return m
+}
+
 // Build implements Widget interface.
 func (m *MenuItemWidget) Build() {
-	if imgui.MenuItemBoolV(Context.PrepareString(m.label.String()), m.shortcut, m.selected, m.enabled) && m.onClick != nil {
-		m.onClick()
+	shortcut, onClick := m.shortcut, m.onClick
+
+	if m.command != "" {
+		if shortcut == "" {
+			shortcut = commandShortcut(m.command)
+		}
+
+		name := m.command
+		onClick = func() { RunCommand(name) }
+	}
+
+	if imgui.MenuItemBoolV(Context.PrepareString(m.label.String()), shortcut, m.selected, m.enabled) && onClick != nil {
+		onClick()
 	}
 }
 
@@ -499,9 +606,9 @@ func Menu(label string) *MenuWidget {
 	}
 }
 
-// Menuf is alias to Menu(fmt.Sprintf(format, args...)).
+// Menuf is alias to Menu(fmt.Sprintf(format, args...)) (format is translated, see GIUContext.PrepareStringf).
 func Menuf(format string, args ...any) *MenuWidget {
-	return Menu(fmt.Sprintf(format, args...))
+	return Menu(Context.PrepareStringf(format, args...))
 }
 
 // Enabled sets whether the menu is enabled.
@@ -533,6 +640,8 @@ type ProgressBarWidget struct {
 	width    float32
 	height   float32
 	overlay  string
+
+	cssAttributes
 }
 
 // ProgressBar creates new ProgressBar.
@@ -557,9 +666,21 @@ func (p *ProgressBarWidget) Overlay(overlay string) *ProgressBarWidget {
 	return p
 }
 
-// Overlayf is alias to Overlay(fmt.Sprintf(format, args...)).
+// Overlayf is alias to Overlay(fmt.Sprintf(format, args...)) (format is translated, see GIUContext.PrepareStringf).
 func (p *ProgressBarWidget) Overlayf(format string, args ...any) *ProgressBarWidget {
-	return p.Overlay(fmt.Sprintf(format, args...))
+	return p.Overlay(Context.PrepareStringf(format, args...))
+}
+
+// Class adds CSS classes to the progress bar (matched by .class selectors).
+func (p *ProgressBarWidget) Class(classes ...string) *ProgressBarWidget {
+	p.addClasses(classes)
+	return p
+}
+
+// CSSID sets CSS ID of the progress bar (matched by #id selectors).
+func (p *ProgressBarWidget) CSSID(id string) *ProgressBarWidget {
+	p.cssID = id
+	return p
 }
 
 // Build implements Widget interface.
@@ -635,7 +756,7 @@ func TabItem(label string) *TabItemWidget {
 
 // TabItemf creates tab item with formated label.
 func TabItemf(format string, args ...any) *TabItemWidget {
-	return TabItem(fmt.Sprintf(format, args...))
+	return TabItem(Context.PrepareStringf(format, args...))
 }
 
 // IsOpen takes a pointer to a boolean.
@@ -689,6 +810,8 @@ type TabBarWidget struct {
 	id       ID
 	flags    TabBarFlags
 	tabItems []*TabItemWidget
+
+	cssAttributes
 }
 
 // TabBar creates new TabBarWidget.
@@ -717,6 +840,18 @@ func (t *TabBarWidget) TabItems(items ...*TabItemWidget) *TabBarWidget {
 	return t
 }
 
+// Class adds CSS classes to the tab bar (matched by .class selectors).
+func (t *TabBarWidget) Class(classes ...string) *TabBarWidget {
+	t.addClasses(classes)
+	return t
+}
+
+// CSSID sets CSS ID of the tab bar (matched by #id selectors).
+func (t *TabBarWidget) CSSID(id string) *TabBarWidget {
+	t.cssID = id
+	return t
+}
+
 // Build implements Widget interface.
 func (t *TabBarWidget) Build() {
 	if imgui.BeginTabBarV(t.id.String(), imgui.TabBarFlags(t.flags)) {
@@ -749,7 +884,7 @@ func Tooltip(tip string) *TooltipWidget {
 
 // Tooltipf sets formated label.
 func Tooltipf(format string, args ...any) *TooltipWidget {
-	return Tooltip(fmt.Sprintf(format, args...))
+	return Tooltip(Context.PrepareStringf(format, args...))
 }
 
 // Layout sets a custom layout of tooltip.
@@ -815,6 +950,7 @@ type ColorEditWidget struct {
 	flags    ColorEditFlags
 	width    float32
 	onChange func()
+	undo     *UndoStack
 }
 
 // ColorEdit creates new ColorEditWidget.
@@ -844,6 +980,12 @@ func (ce *ColorEditWidget) OnChange(cb func()) *ColorEditWidget {
 	return ce
 }
 
+// UndoStack sets stack recording changes of the color editor's value (see UndoStack).
+func (ce *ColorEditWidget) UndoStack(stack *UndoStack) *ColorEditWidget {
+	ce.undo = stack
+	return ce
+}
+
 // Flags allows to set ColorEditFlags.
 func (ce *ColorEditWidget) Flags(f ColorEditFlags) *ColorEditWidget {
 	ce.flags = f
@@ -858,6 +1000,7 @@ func (ce *ColorEditWidget) Size(width float32) *ColorEditWidget {
 
 // Build implements Widget interface.
 func (ce *ColorEditWidget) Build() {
+	before := *ce.color
 	c := ToVec4Color(*ce.color)
 	col := [4]float32{
 		c.X,
@@ -886,6 +1029,8 @@ func (ce *ColorEditWidget) Build() {
 		}
 	}
 
+	trackEdit(ce.undo, ce.label, ce.color, before, nil)
+
 	if ce.width > 0 {
 		imgui.PopItemWidth()
 	}
@@ -66,6 +66,8 @@ type WindowWidget struct {
 	x, y          float32
 	width, height float32
 	bringToFront  bool
+	dockTo        string
+	shortcutScope string
 }
 
 // Window creates a WindowWidget.
@@ -106,6 +108,22 @@ func (w *WindowWidget) Pos(x, y float32) *WindowWidget {
 	return w
 }
 
+// DockTo docks the window into a DockSpace or into a named node of its DockLayout
+// (target is the DockSpace's ID or the node's name) when the window appears for the first time.
+// Like Pos, it is ignored if the window's arrangement was restored from the user file (see MasterWindow.SetUserFile).
+// NOTE: the DockSpace should be built before the window. Names of nodes are known only if the DockLayout was applied.
+func (w *WindowWidget) DockTo(target string) *WindowWidget {
+	w.dockTo = target
+	return w
+}
+
+// ShortcutScope makes the window a scope of key bindings named name (see ShortcutScope and KeyBinding.Scope),
+// so they are active while the window (or its child) is focused.
+func (w *WindowWidget) ShortcutScope(name string) *WindowWidget {
+	w.shortcutScope = name
+	return w
+}
+
 // Layout is a final step of the window setup.
 // it should be called to add a layout to the window and build it.
 func (w *WindowWidget) Layout(widgets ...Widget) {
@@ -123,6 +141,10 @@ func (w *WindowWidget) Layout(widgets ...Widget) {
 		imgui.SetNextWindowSizeV(imgui.Vec2{X: w.width, Y: w.height}, imgui.CondFirstUseEver)
 	}
 
+	if id, ok := Context.dockNodes[w.dockTo]; ok && w.dockTo != "" {
+		imgui.SetNextWindowDockIDV(id, imgui.CondFirstUseEver)
+	}
+
 	if w.bringToFront {
 		imgui.SetNextWindowFocus()
 
@@ -146,7 +168,11 @@ func (w *WindowWidget) Layout(widgets ...Widget) {
 	showed := imgui.BeginV(Context.PrepareString(w.title), w.open, imgui.WindowFlags(w.flags))
 
 	if showed {
-		Layout(widgets).Build()
+		if w.shortcutScope != "" {
+			ShortcutScope(w.shortcutScope, widgets...).Build()
+		} else {
+			Layout(widgets).Build()
+		}
 	}
 
 	imgui.End()
@@ -191,6 +217,16 @@ func (w *WindowWidget) RegisterKeyboardShortcuts(s ...WindowShortcut) *WindowWid
 	return w
 }
 
+// RegisterNamedShortcuts adds local (window-level) keyboard shortcuts named by the map keys,
+// so their keys may be changed by a Keymap (see KeyBindingHandler.RegisterNamedShortcuts).
+func (w *WindowWidget) RegisterNamedShortcuts(s map[string]WindowShortcut) *WindowWidget {
+	if w.HasFocus() {
+		registerNamedShortcuts(s, LocalShortcut)
+	}
+
+	return w
+}
+
 func (w *WindowWidget) getStateID() ID {
 	return ID(fmt.Sprintf("%s_windowState", w.title))
 }
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const giuImportPath = "github.com/AllenDang/giu"
+
+// giuFunctions maps giu functions to indexes of their arguments which go through Context.PrepareString.
+var giuFunctions = map[string][]int{
+	"Label":             {0},
+	"Labelf":            {0},
+	"Button":            {0},
+	"Buttonf":           {0},
+	"SmallButton":       {0},
+	"SmallButtonf":      {0},
+	"Checkbox":          {0},
+	"RadioButton":       {0},
+	"Selectable":        {0},
+	"Selectablef":       {0},
+	"TreeNode":          {0},
+	"TreeNodef":         {0},
+	"Link":              {0},
+	"Linkf":             {0},
+	"BulletText":        {0},
+	"BulletTextf":       {0},
+	"MenuItem":          {0},
+	"MenuItemf":         {0},
+	"Menu":              {0},
+	"Menuf":             {0},
+	"TabItem":           {0},
+	"TabItemf":          {0},
+	"Tooltip":           {0},
+	"Tooltipf":          {0},
+	"Window":            {0},
+	"TableColumn":       {0},
+	"TreeTableRow":      {0},
+	"ComboCustom":       {0, 1},
+	"Combo":             {0, 1, 2},
+	"Plot":              {0},
+	"ProgressIndicator": {0},
+	"Markdown":          {0},
+}
+
+// giuMethods are methods of widgets (called on a chain started by giu function)
+// whose first argument goes through Context.PrepareString.
+var giuMethods = map[string]bool{
+	"Label":    true,
+	"Labelf":   true,
+	"Hint":     true,
+	"Overlay":  true,
+	"Overlayf": true,
+}
+
+// contextMethods are methods of giu.Context which translate their first argument.
+// The value tells whether the string is a plural key.
+var contextMethods = map[string]bool{
+	"PrepareString":  false,
+	"PrepareStringf": false,
+	"Tr":             false,
+	"TrN":            true,
+}
+
+// message is a translatable string found in the source.
+type message struct {
+	key        string
+	plural     bool
+	references []string
+}
+
+type extractor struct {
+	fset     *token.FileSet
+	messages map[string]*message
+	// giuName is the name giu package is imported as in the current file ("." for dot-import)
+	giuName string
+}
+
+func newExtractor() *extractor {
+	return &extractor{
+		fset:     token.NewFileSet(),
+		messages: make(map[string]*message),
+	}
+}
+
+// addPath extracts strings from a .go file or a directory.
+// Directories ending with "/..." are walked recursively (vendor, testdata and hidden directories are skipped).
+func (e *extractor) addPath(path string) error {
+	recursive := false
+	if rest, ok := strings.CutSuffix(path, "..."); ok {
+		path, recursive = filepath.Clean(rest), true
+	}
+
+	return filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if name == path {
+				return nil
+			}
+
+			base := d.Name()
+			if !recursive || base == "vendor" || base == "testdata" || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		return e.addFile(name)
+	})
+}
+
+func (e *extractor) addFile(name string) error {
+	file, err := parser.ParseFile(e.fset, name, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	e.giuName = ""
+
+	for _, imp := range file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path != giuImportPath {
+			continue
+		}
+
+		e.giuName = "giu"
+		if imp.Name != nil {
+			e.giuName = imp.Name.Name
+		}
+	}
+
+	if e.giuName == "" || e.giuName == "_" {
+		return nil
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			e.addCall(call)
+		}
+
+		return true
+	})
+
+	return nil
+}
+
+func (e *extractor) addCall(call *ast.CallExpr) {
+	if name, ok := e.giuFunction(call.Fun); ok {
+		for _, i := range giuFunctions[name] {
+			if i < len(call.Args) {
+				e.addArg(call.Args[i], false)
+			}
+		}
+
+		return
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+
+	if plural, ok := contextMethods[sel.Sel.Name]; ok && e.isContext(sel.X) {
+		e.addArg(call.Args[0], plural)
+		return
+	}
+
+	if giuMethods[sel.Sel.Name] && e.isGiuChain(sel.X) {
+		e.addArg(call.Args[0], false)
+	}
+}
+
+// giuFunction returns name of giu function referenced by expr (e.g. giu.Label).
+func (e *extractor) giuFunction(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name, e.giuName == "."
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		return x.Sel.Name, ok && pkg.Name == e.giuName
+	default:
+		return "", false
+	}
+}
+
+// isContext reports whether expr is giu.Context.
+func (e *extractor) isContext(expr ast.Expr) bool {
+	name, ok := e.giuFunction(expr)
+	return ok && name == "Context"
+}
+
+// isGiuChain reports whether expr is a chain of method calls started by giu function (e.g. giu.SliderInt(&v).Size(100)).
+func (e *extractor) isGiuChain(expr ast.Expr) bool {
+	for {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.CallExpr:
+			if _, ok := e.giuFunction(x.Fun); ok {
+				return true
+			}
+
+			sel, ok := x.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return false
+			}
+
+			expr = sel.X
+		default:
+			return false
+		}
+	}
+}
+
+// addArg adds constant strings of arg (string literals, their concatenations and string slice literals).
+func (e *extractor) addArg(arg ast.Expr, plural bool) {
+	if lit, ok := arg.(*ast.CompositeLit); ok {
+		for _, elt := range lit.Elts {
+			e.addArg(elt, plural)
+		}
+
+		return
+	}
+
+	s, ok := constantString(arg)
+	if !ok {
+		return
+	}
+
+	// the same as BasicTranslator.Translate does
+	key := strings.Split(s, "##")[0]
+	if key == "" {
+		return
+	}
+
+	pos := e.fset.Position(arg.Pos())
+	reference := fmt.Sprintf("%s:%d", filepath.ToSlash(pos.Filename), pos.Line)
+
+	m, ok := e.messages[key]
+	if !ok {
+		m = &message{key: key}
+		e.messages[key] = m
+	}
+
+	m.plural = m.plural || plural
+	m.references = append(m.references, reference)
+}
+
+func constantString(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return "", false
+		}
+
+		s, err := strconv.Unquote(x.Value)
+
+		return s, err == nil
+	case *ast.ParenExpr:
+		return constantString(x.X)
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return "", false
+		}
+
+		left, ok := constantString(x.X)
+		if !ok {
+			return "", false
+		}
+
+		right, ok := constantString(x.Y)
+
+		return left + right, ok
+	default:
+		return "", false
+	}
+}
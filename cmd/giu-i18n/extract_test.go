@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSource = `package app
+
+import g "github.com/AllenDang/giu"
+
+func loop(files []string, v *int32) {
+	g.Window("Main window").Layout(
+		g.Label("Hello"),
+		g.Buttonf("Open %s##open", files[0]),
+		g.Combo("Files", "None", []string{"First", "Second"}, v),
+		g.SliderInt(v, 0, 10).Label("Volume" + " level"),
+		g.Label(g.Context.TrN("{count} files", len(files), nil)),
+		g.Label(files[0]),
+		g.Button("##hidden"),
+	)
+
+	other.Label("not giu")
+}
+`
+
+func TestExtractor(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "ui"), 0o755), "creating directory")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ui", "loop.go"), []byte(testSource), 0o600), "writing source")
+
+	e := newExtractor()
+	if !assert.NoError(t, e.addPath(dir+"/..."), "unexpected extraction error") {
+		return
+	}
+
+	keys := make(map[string]bool)
+	for key, m := range e.messages {
+		keys[key] = m.plural
+	}
+
+	assert.Equal(t, map[string]bool{
+		"Main window":   false,
+		"Hello":         false,
+		"Open %s":       false,
+		"Files":         false,
+		"None":          false,
+		"First":         false,
+		"Second":        false,
+		"Volume level":  false,
+		"{count} files": true,
+	}, keys, "unexpected messages")
+
+	assert.Equal(t, []string{filepath.ToSlash(filepath.Join(dir, "ui", "loop.go")) + ":7"}, e.messages["Hello"].references, "unexpected reference")
+
+	e = newExtractor()
+	assert.NoError(t, e.addPath(dir), "unexpected extraction error")
+	assert.Empty(t, e.messages, "subdirectory searched without /...")
+}
+
+func TestWritePO(t *testing.T) {
+	data := writePO(map[string]*message{
+		"Hello":         {key: "Hello", references: []string{"main.go:1"}},
+		"{count} files": {key: "{count} files", plural: true},
+	})
+
+	assert.Contains(t, string(data), "#: main.go:1\nmsgid \"Hello\"\nmsgstr \"\"\n", "unexpected message")
+	assert.Contains(t, string(data), "msgid \"{count} files\"\nmsgid_plural \"{count} files\"\nmsgstr[0] \"\"\nmsgstr[1] \"\"\n", "unexpected plural message")
+}
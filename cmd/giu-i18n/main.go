@@ -0,0 +1,87 @@
+// Package main extracts translatable strings from giu applications.
+// It writes a catalog template (.po or JSON) which can be translated and loaded by giu.BasicTranslator.
+// use -help for more.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Println("Usage: giu-i18n [flags] [path/to/project/...]")
+		fmt.Println("Paths ending with /... are searched recursively (default: ./...).")
+		fmt.Println("Flags:")
+		flag.PrintDefaults()
+
+		os.Exit(0)
+	}
+
+	var output string
+
+	flag.StringVar(&output, "o", "", "output file (default: stdout)")
+
+	var format string
+
+	flag.StringVar(&format, "format", "", "output format [po, json] (default: by extension of -o or po)")
+
+	flag.Parse()
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(output), ".")
+		if format == "pot" || format == "" {
+			format = "po"
+		}
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	e := newExtractor()
+
+	for _, path := range paths {
+		if err := e.addPath(path); err != nil {
+			log.Fatalf("Failed to extract strings: %v", err)
+		}
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case "po":
+		data = writePO(e.messages)
+	case "json":
+		data, err = writeJSON(e.messages)
+	default:
+		log.Fatalf("Unknown format %s", format)
+	}
+
+	if err != nil {
+		log.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	if output == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			log.Fatalf("Failed to write catalog: %v", err)
+		}
+
+		return
+	}
+
+	const newFileMode = 0o644
+	if err := os.WriteFile(output, data, newFileMode); err != nil {
+		log.Fatalf("Failed to save %s: %v", output, err)
+	}
+
+	log.Printf("%d strings written to %s", len(e.messages), output)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// writePO writes messages as gettext template (.pot).
+func writePO(messages map[string]*message) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("# Translation template generated by giu-i18n.\n")
+	buf.WriteString("msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+
+	for _, key := range slices.Sorted(maps.Keys(messages)) {
+		m := messages[key]
+
+		buf.WriteString("\n")
+
+		for _, ref := range m.references {
+			fmt.Fprintf(&buf, "#: %s\n", ref)
+		}
+
+		id := key
+		if context, rest, ok := strings.Cut(key, "\x04"); ok {
+			fmt.Fprintf(&buf, "msgctxt %s\n", strconv.Quote(context))
+			id = rest
+		}
+
+		fmt.Fprintf(&buf, "msgid %s\n", strconv.Quote(id))
+
+		if !m.plural {
+			buf.WriteString("msgstr \"\"\n")
+			continue
+		}
+
+		fmt.Fprintf(&buf, "msgid_plural %s\nmsgstr[0] \"\"\nmsgstr[1] \"\"\n", strconv.Quote(id))
+	}
+
+	return buf.Bytes()
+}
+
+// writeJSON writes messages as JSON locale file (see giu.BasicTranslator.LoadLanguage).
+// Plural keys get "one" and "other" forms; translators add forms needed by their language.
+func writeJSON(messages map[string]*message) ([]byte, error) {
+	template := make(map[string]any, len(messages))
+
+	for key, m := range messages {
+		if m.plural {
+			template[key] = map[string]string{"one": "", "other": ""}
+		} else {
+			template[key] = ""
+		}
+	}
+
+	data, err := json.MarshalIndent(template, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
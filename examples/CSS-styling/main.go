@@ -19,6 +19,12 @@ func loop() {
 		giu.CSSTag("label").To(
 			giu.Label("I'ma  normal label"),
 		),
+		giu.CSSTag("toolbar").To(
+			giu.Row(
+				giu.Button("I'm styled by class"),
+				giu.Button("Delete").Class("danger"),
+			),
+		),
 		giu.Plot("styled plot").Plots(
 			giu.Line("Plot 1", []float64{0, 1, 2, 3, 4, 5}),
 		),
@@ -10,9 +10,9 @@ var (
 
 func loop() {
 	giu.Window("Window 2").
-		RegisterKeyboardShortcuts(
-			giu.WindowShortcut{Key: giu.KeyZ, Modifier: giu.ModControl, Callback: func() { checkbox2 = !checkbox2 }},
-		).
+		RegisterNamedShortcuts(map[string]giu.WindowShortcut{
+			"checkbox2.toggle": {Key: giu.KeyZ, Modifier: giu.ModControl, Callback: func() { checkbox2 = !checkbox2 }},
+		}).
 		Layout(
 			giu.Checkbox("Press Ctrl+C to change my state - I'm a global shortcut", &checkbox1),
 			giu.Checkbox("Press Ctrl+Z to change my state - I'm a local shortcut", &checkbox2),
@@ -22,17 +22,26 @@ func loop() {
 		Layout(
 			giu.Checkbox("Press Ctrl+C to change my state - I'm a global shortcut", &checkbox1),
 		)
+
+	giu.Window("Keymap").
+		Layout(
+			giu.Label("Click a shortcut to change it:"),
+			giu.KeymapEditor(),
+		)
+
+	// Ctrl+Shift+P lists named shortcuts
+	giu.CommandPalette().Build()
 }
 
 func main() {
 	wnd := giu.NewMasterWindow("keyboard shortcuts", 640, 480, 0).
-		RegisterKeyboardShortcuts(
-			giu.WindowShortcut{
+		RegisterNamedShortcuts(map[string]giu.WindowShortcut{
+			"checkbox1.toggle": {
 				Key:      giu.KeyC,
 				Modifier: giu.ModControl,
 				Callback: func() { checkbox1 = !checkbox1 },
 			},
-		)
+		})
 
 	wnd.Run(loop)
 }
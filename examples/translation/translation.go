@@ -5,7 +5,7 @@ import "github.com/AllenDang/giu"
 
 var (
 	// here we define our multi-language dictionary.
-	// You could also do that e.g. with JSON.
+	// You could also load it from .po/.mo, JSON or YAML files (see BasicTranslator.LoadLanguages).
 	languageDefs = map[string]map[string]string{
 		"en": {}, // as we write our UI in english, the default value of the text will be fine (see (*BasicTranslator).Translate for more)
 		"pl": {
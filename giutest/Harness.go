@@ -0,0 +1,259 @@
+// Package giutest provides a harness for testing giu layouts without a display.
+//
+// It runs frames on giu.HeadlessBackend, records every widget built by giu.Layout
+// (with its ID, label and screen rectangle) and allows to simulate user input.
+//
+// Usage:
+//
+//	func TestSave(t *testing.T) {
+//		saved := false
+//		h := giutest.New(t, 800, 600)
+//		h.Run(2, func() giu.Layout {
+//			return giu.Layout{
+//				giu.Button("Save").OnClick(func() { saved = true }),
+//			}
+//		})
+//
+//		h.Click("Save")
+//
+//		if !saved {
+//			t.Error("Save wasn't clicked")
+//		}
+//	}
+package giutest
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+
+	"github.com/AllenDang/giu"
+)
+
+// Item represents a widget recorded during the last frame.
+type Item struct {
+	Widget giu.Widget
+	// ID is the widget's ID as it is stored in the widget (e.g. "Save##0").
+	ID giu.ID
+	// Label is an ID with ##suffix trimmed (e.g. "Save").
+	Label string
+	// ImguiID is an ID of the last imgui item submitted by the widget.
+	ImguiID imgui.ID
+	// Rect is a screen rectangle of the last imgui item submitted by the widget.
+	Rect image.Rectangle
+}
+
+// Center returns the center of item's rectangle.
+func (i Item) Center() image.Point {
+	return image.Pt((i.Rect.Min.X+i.Rect.Max.X)/2, (i.Rect.Min.Y+i.Rect.Max.Y)/2)
+}
+
+var _ giu.BuildObserver = &Harness{}
+
+// Harness runs a giu layout on a headless master window.
+type Harness struct {
+	tb      testing.TB
+	backend *giu.HeadlessBackend
+	window  *giu.MasterWindow
+
+	layout func() giu.Layout
+
+	// items recorded in the last complete frame
+	items []Item
+	// items recorded in the current frame
+	current []Item
+}
+
+// New creates a new Harness with a headless master window of the given size.
+// The window and its imgui context are destroyed when the test finishes.
+func New(tb testing.TB, width, height int) *Harness {
+	tb.Helper()
+
+	h := &Harness{
+		tb:      tb,
+		backend: giu.NewHeadlessBackend(),
+	}
+
+	h.window = giu.NewMasterWindowWithBackend(h.backend, tb.Name(), width, height, 0)
+	giu.Context.AddBuildObserver(h)
+
+	tb.Cleanup(h.destroy)
+
+	return h
+}
+
+// destroy destroys the window and imgui context of the harness.
+func (h *Harness) destroy() {
+	giu.Context.RemoveBuildObserver(h)
+	h.backend.Destroy()
+	imgui.DestroyContext()
+}
+
+// Backend returns the headless backend used by the harness.
+func (h *Harness) Backend() *giu.HeadlessBackend {
+	return h.backend
+}
+
+// Window returns the master window used by the harness.
+func (h *Harness) Window() *giu.MasterWindow {
+	return h.window
+}
+
+// Run builds layout (in a SingleWindow) for the given number of frames.
+// layout is called on each frame, just like the loop function passed to MasterWindow.Run.
+func (h *Harness) Run(frames int, layout func() giu.Layout) {
+	h.tb.Helper()
+
+	h.layout = layout
+	h.backend.SetFrameLimit(frames)
+	h.window.Run(h.loop)
+}
+
+// Step renders n more frames.
+func (h *Harness) Step(n int) {
+	h.tb.Helper()
+
+	if h.layout == nil {
+		h.tb.Fatal("giutest: Step called before Run")
+	}
+
+	h.backend.Step(n)
+}
+
+func (h *Harness) loop() {
+	h.current = nil
+
+	giu.SingleWindow().Layout(h.layout()...)
+
+	h.items = h.current
+}
+
+// BeforeBuild implements giu.BuildObserver.
+func (h *Harness) BeforeBuild(_ giu.Widget) {
+	// noop
+}
+
+// AfterBuild implements giu.BuildObserver.
+func (h *Harness) AfterBuild(w giu.Widget) {
+	id, ok := giu.WidgetID(w)
+	if !ok {
+		return
+	}
+
+	rectMin, rectMax := imgui.ItemRectMin(), imgui.ItemRectMax()
+
+	h.current = append(h.current, Item{
+		Widget:  w,
+		ID:      id,
+		Label:   strings.Split(id.String(), "##")[0],
+		ImguiID: imgui.ItemID(),
+		Rect:    image.Rect(int(rectMin.X), int(rectMin.Y), int(rectMax.X), int(rectMax.Y)),
+	})
+}
+
+// Items returns all items recorded in the last frame.
+func (h *Harness) Items() []Item {
+	return h.items
+}
+
+// FindByLabel returns the first item built in the last frame with the given label.
+// Label is compared with widget's ID with ##suffix trimmed
+// (so that "Save" matches giu.Button("Save") which ID is "Save##0").
+func (h *Harness) FindByLabel(label string) (Item, bool) {
+	for _, item := range h.items {
+		if item.Label == label {
+			return item, true
+		}
+	}
+
+	return Item{}, false
+}
+
+// FindByID returns the first item built in the last frame with exactly the given ID.
+func (h *Harness) FindByID(id giu.ID) (Item, bool) {
+	for _, item := range h.items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+
+	return Item{}, false
+}
+
+// MustFind does FindByLabel, but fails the test if there is no such an item.
+func (h *Harness) MustFind(label string) Item {
+	h.tb.Helper()
+
+	item, ok := h.FindByLabel(label)
+	if !ok {
+		h.tb.Fatalf("giutest: no item labeled %q in the last frame", label)
+	}
+
+	return item
+}
+
+// Click clicks (with the left mouse button) the center of the item labeled label.
+// Frames are rendered until the click is processed by imgui.
+func (h *Harness) Click(label string) {
+	h.tb.Helper()
+
+	h.ClickAt(h.MustFind(label).Center(), giu.MouseButtonLeft)
+}
+
+// ClickAt clicks the mouse button at the screen position pos.
+func (h *Harness) ClickAt(pos image.Point, button giu.MouseButton) {
+	h.tb.Helper()
+
+	h.backend.Click(button, float32(pos.X), float32(pos.Y))
+	h.Step(1)
+}
+
+// Drag presses the left mouse button at from, moves the mouse to to and releases the button there.
+func (h *Harness) Drag(from, to image.Point) {
+	h.tb.Helper()
+
+	h.backend.MoveMouse(float32(from.X), float32(from.Y))
+	h.Step(1)
+	h.backend.MouseButton(giu.MouseButtonLeft, true)
+	h.Step(1)
+	h.backend.MoveMouse(float32(to.X), float32(to.Y))
+	h.Step(1)
+	h.backend.MouseButton(giu.MouseButtonLeft, false)
+	// widgets handle the release in the next frame, so render one more to show its effect
+	h.Step(2)
+}
+
+// Hover moves the mouse to the center of the item labeled label.
+func (h *Harness) Hover(label string) {
+	h.tb.Helper()
+
+	pos := h.MustFind(label).Center()
+	h.backend.MoveMouse(float32(pos.X), float32(pos.Y))
+	h.Step(1)
+}
+
+// Type clicks the item labeled label (e.g. InputTextWidget) to focus it
+// and types text into it.
+func (h *Harness) Type(label, text string) {
+	h.tb.Helper()
+
+	h.Click(label)
+	h.backend.Type(text)
+	h.Step(1)
+}
+
+// PressKey presses and releases key with modifiers mod.
+// The event is delivered to imgui and to giu.Context.InputHandler (keyboard shortcuts).
+func (h *Harness) PressKey(key giu.Key, mod giu.Modifier) {
+	h.tb.Helper()
+
+	h.backend.KeyPress(key, mod)
+	h.Step(1)
+}
+
+// State returns a state stored in giu.Context under id (see giu.Context.GetState).
+func (h *Harness) State(id giu.ID) any {
+	return giu.Context.GetState(id)
+}
@@ -0,0 +1,83 @@
+package giutest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+)
+
+func Test_Harness_FindByLabel(t *testing.T) {
+	h := New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{
+			giu.Label("Hello"),
+			giu.Button("Save").Size(80, 30),
+		}
+	})
+
+	item, ok := h.FindByLabel("Save")
+	if assert.True(t, ok, "button not found") {
+		assert.Equal(t, 80, item.Rect.Dx(), "unexpected button width")
+		assert.Equal(t, 30, item.Rect.Dy(), "unexpected button height")
+		assert.IsType(t, &giu.ButtonWidget{}, item.Widget, "unexpected widget type")
+	}
+
+	_, ok = h.FindByLabel("Cancel")
+	assert.False(t, ok, "found item that doesn't exist")
+}
+
+func Test_Harness_Click(t *testing.T) {
+	clicked := 0
+
+	h := New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{
+			giu.Row(
+				giu.Button("Save").OnClick(func() { clicked++ }),
+				giu.Button("Cancel"),
+			),
+		}
+	})
+
+	h.Click("Save")
+	assert.Equal(t, 1, clicked, "OnClick wasn't called exactly once")
+
+	h.Click("Cancel")
+	assert.Equal(t, 1, clicked, "OnClick called by another button")
+}
+
+func Test_Harness_Type(t *testing.T) {
+	var (
+		value   string
+		changed bool
+	)
+
+	h := New(t, 400, 300)
+	h.Run(2, func() giu.Layout {
+		return giu.Layout{
+			giu.InputText(&value).Label("Name").OnChange(func() { changed = true }),
+		}
+	})
+
+	h.Type("Name", "giu")
+	assert.Equal(t, "giu", value, "unexpected input value")
+	assert.True(t, changed, "OnChange wasn't called")
+}
+
+func Test_Harness_PressKey(t *testing.T) {
+	pressed := false
+
+	h := New(t, 400, 300)
+	h.Window().RegisterKeyboardShortcuts(giu.WindowShortcut{
+		Key:      giu.KeyS,
+		Modifier: giu.ModControl,
+		Callback: func() { pressed = true },
+	})
+
+	h.Run(1, func() giu.Layout { return nil })
+	h.PressKey(giu.KeyS, giu.ModControl)
+
+	assert.True(t, pressed, "shortcut wasn't triggered")
+}
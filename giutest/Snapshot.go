@@ -0,0 +1,126 @@
+package giutest
+
+import (
+	"errors"
+	"flag"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AllenDang/giu"
+)
+
+// DefaultTolerance is a maximal difference of a single color channel, for which pixels are still considered equal.
+// It compensates floating point differences between platforms.
+const DefaultTolerance = 2
+
+var updateSnapshots = flag.Bool("giutest.update", false, "update golden images instead of comparing with them")
+
+// Snapshot rasterizes the last frame (see giu.FrameDrawData.Rasterize).
+func (h *Harness) Snapshot() *image.RGBA {
+	h.tb.Helper()
+
+	img := h.backend.Screenshot()
+	if img == nil {
+		h.tb.Fatal("giutest: no frame to snapshot; call Run first")
+	}
+
+	return img
+}
+
+// MatchSnapshot compares the last frame with the golden PNG image (see AssertGolden).
+func (h *Harness) MatchSnapshot(goldenPath string) {
+	h.tb.Helper()
+
+	AssertGolden(h.tb, goldenPath, h.Snapshot())
+}
+
+// AssertGolden compares img with the PNG image stored in goldenPath.
+// If they differ, the test fails and two files are written next to the golden image:
+// - <name>.actual.png - img
+// - <name>.diff.png - image where mismatched pixels are red
+//
+// Run tests with -giutest.update to (re)create golden images.
+func AssertGolden(tb testing.TB, goldenPath string, img image.Image) {
+	tb.Helper()
+
+	if *updateSnapshots {
+		if err := giu.SaveImage(goldenPath, img); err != nil {
+			tb.Fatalf("giutest: updating golden image: %v", err)
+		}
+
+		return
+	}
+
+	golden, err := giu.LoadImage(goldenPath)
+	if errors.Is(err, os.ErrNotExist) {
+		tb.Fatalf("giutest: golden image %s doesn't exist; run tests with -giutest.update to create it", goldenPath)
+	} else if err != nil {
+		tb.Fatalf("giutest: loading golden image: %v", err)
+	}
+
+	diff, mismatched := CompareImages(golden, img, DefaultTolerance)
+	if mismatched == 0 {
+		return
+	}
+
+	base := strings.TrimSuffix(goldenPath, ".png")
+	actualPath, diffPath := base+".actual.png", base+".diff.png"
+
+	if err := giu.SaveImage(actualPath, img); err != nil {
+		tb.Errorf("giutest: saving actual image: %v", err)
+	}
+
+	if err := giu.SaveImage(diffPath, diff); err != nil {
+		tb.Errorf("giutest: saving diff image: %v", err)
+	}
+
+	tb.Errorf("giutest: image differs from %s in %d pixels (see %s and %s)", goldenPath, mismatched, actualPath, diffPath)
+}
+
+// CompareImages compares two images pixel by pixel.
+// Pixels are equal, if none of their channels differs by more than tolerance.
+// It returns a diff image (mismatched pixels are red, the others are faded expected image)
+// and number of mismatched pixels.
+// If images sizes differ, all pixels outside of the common area are considered mismatched.
+func CompareImages(expected, actual image.Image, tolerance uint8) (diff *image.RGBA, mismatched int) {
+	expectedRgba, actualRgba := giu.ImageToRgba(expected), giu.ImageToRgba(actual)
+	bounds := expectedRgba.Bounds().Union(actualRgba.Bounds())
+	diff = image.NewRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pt := image.Pt(x, y)
+			if !pt.In(expectedRgba.Bounds()) || !pt.In(actualRgba.Bounds()) {
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				mismatched++
+
+				continue
+			}
+
+			e, a := expectedRgba.RGBAAt(x, y), actualRgba.RGBAAt(x, y)
+			if channelDiff(e.R, a.R) > tolerance || channelDiff(e.G, a.G) > tolerance ||
+				channelDiff(e.B, a.B) > tolerance || channelDiff(e.A, a.A) > tolerance {
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				mismatched++
+
+				continue
+			}
+
+			gray := uint8((uint16(e.R) + uint16(e.G) + uint16(e.B)) / 3 / 4)
+			diff.SetRGBA(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+
+	return diff, mismatched
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
@@ -0,0 +1,87 @@
+package giutest
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AllenDang/giu"
+)
+
+func Test_CompareImages(t *testing.T) {
+	expected := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	actual := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	expected.SetRGBA(0, 0, color.RGBA{R: 100, A: 255})
+	actual.SetRGBA(0, 0, color.RGBA{R: 101, A: 255})
+	expected.SetRGBA(1, 1, color.RGBA{G: 200, A: 255})
+
+	diff, mismatched := CompareImages(expected, actual, DefaultTolerance)
+	assert.Equal(t, 1, mismatched, "unexpected number of mismatched pixels")
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, diff.RGBAAt(1, 1), "mismatched pixel not marked")
+
+	_, mismatched = CompareImages(expected, image.NewRGBA(image.Rect(0, 0, 3, 2)), DefaultTolerance)
+	assert.Equal(t, 4, mismatched, "size difference not detected")
+}
+
+// blendOver returns src drawn over an opaque dst.
+func blendOver(src, dst color.Color) color.RGBA {
+	s := color.NRGBAModel.Convert(src).(color.NRGBA)
+	d := color.RGBAModel.Convert(dst).(color.RGBA)
+	mix := func(a, b uint8) uint8 {
+		return uint8((int(a)*int(s.A) + int(b)*(255-int(s.A)) + 127) / 255)
+	}
+
+	return color.RGBA{R: mix(s.R, d.R), G: mix(s.G, d.G), B: mix(s.B, d.B), A: 255}
+}
+
+func assertPixel(t *testing.T, expected color.RGBA, img *image.RGBA, p image.Point, what string) {
+	t.Helper()
+
+	single := func(c color.RGBA) *image.RGBA {
+		i := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		i.SetRGBA(0, 0, c)
+
+		return i
+	}
+
+	_, mismatched := CompareImages(single(expected), single(img.RGBAAt(p.X, p.Y)), DefaultTolerance)
+	assert.Zero(t, mismatched, "%s: expected %v at %v, got %v", what, expected, p, img.RGBAAt(p.X, p.Y))
+}
+
+func Test_Snapshot_Themes(t *testing.T) {
+	themes := map[string]func() *giu.StyleSetter{
+		"default": giu.DefaultTheme,
+		"light":   giu.LightTheme,
+	}
+
+	for name, theme := range themes {
+		t.Run(name, func(t *testing.T) {
+			const width, height = 200, 100
+
+			ss := theme()
+
+			h := New(t, width, height)
+			h.Window().SetStyle(ss)
+			h.Run(2, func() giu.Layout {
+				return giu.Layout{
+					giu.Button("Themed").Size(120, 40),
+				}
+			})
+
+			img := h.Snapshot()
+			windowBg := blendOver(ss.GetColor(giu.StyleColorWindowBg), color.Black)
+
+			assertPixel(t, windowBg, img, image.Pt(width-3, height-3), "window background")
+
+			button := h.MustFind("Themed").Rect
+			assertPixel(t, blendOver(ss.GetColor(giu.StyleColorButton), windowBg), img,
+				image.Pt(button.Min.X+2, button.Min.Y+button.Dy()/2), "button background")
+
+			h.MatchSnapshot(filepath.Join("testdata", "theme_"+name+".png"))
+		})
+	}
+}